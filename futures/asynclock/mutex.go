@@ -0,0 +1,84 @@
+// Package asynclock provides locks whose acquisition is expressed as a future,
+// so that waiting for a lock composes with timeouts and cancellation.
+package asynclock
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+// Mutex is a mutual exclusion lock granted to waiters in FIFO order. The zero
+// value is an unlocked mutex.
+//
+// Lock returns a future instead of blocking. Cancelling that future before it
+// resolves withdraws the request; if Cancel reports false the lock has already
+// been granted and must be released with Unlock.
+type Mutex struct {
+	mu      sync.Mutex
+	locked  bool
+	waiters list.List // of *futures.Promise[struct{}]
+}
+
+// Lock returns a future that resolves once the caller holds the lock.
+func (m *Mutex) Lock() *futures.Future[struct{}] {
+	p := futures.NewPromise[struct{}]()
+
+	m.mu.Lock()
+	if !m.locked {
+		m.locked = true
+		m.mu.Unlock()
+		p.Complete(struct{}{})
+		return p.Future()
+	}
+	elem := m.waiters.PushBack(p)
+	m.mu.Unlock()
+
+	// A waiter whose future is cancelled gives up its place in the queue.
+	p.Future().OnFailure(func(error) {
+		m.mu.Lock()
+		m.waiters.Remove(elem)
+		m.mu.Unlock()
+	})
+	return p.Future()
+}
+
+// TryLock acquires the lock if it is free and nobody is queued for it.
+func (m *Mutex) TryLock() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.locked {
+		return false
+	}
+	m.locked = true
+	return true
+}
+
+// Unlock releases the lock, handing it to the longest-waiting caller if any.
+// It panics if the mutex is not locked.
+func (m *Mutex) Unlock() {
+	for {
+		m.mu.Lock()
+		if !m.locked {
+			m.mu.Unlock()
+			panic("asynclock: unlock of unlocked mutex")
+		}
+
+		front := m.waiters.Front()
+		if front == nil {
+			m.locked = false
+			m.mu.Unlock()
+			return
+		}
+		m.waiters.Remove(front)
+		m.mu.Unlock()
+
+		// The lock stays held while it is handed over. Complete fails only if
+		// the waiter was cancelled meanwhile, in which case try the next one.
+		if front.Value.(*futures.Promise[struct{}]).Complete(struct{}{}) {
+			return
+		}
+	}
+}
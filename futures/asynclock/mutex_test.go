@@ -0,0 +1,54 @@
+package asynclock_test
+
+import (
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/asynclock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutexGrantsInFIFOOrder(t *testing.T) {
+	var m asynclock.Mutex
+
+	first := m.Lock()
+	assert.Equal(t, futures.Fulfilled, first.State())
+
+	second := m.Lock()
+	third := m.Lock()
+	assert.Equal(t, futures.Pending, second.State())
+	assert.False(t, m.TryLock())
+
+	m.Unlock()
+	_, err := second.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, futures.Pending, third.State())
+
+	m.Unlock()
+	_, err = third.Result()
+	assert.NoError(t, err)
+
+	m.Unlock()
+	assert.True(t, m.TryLock())
+}
+
+func TestMutexCancelledWaiterIsSkipped(t *testing.T) {
+	var m asynclock.Mutex
+	m.Lock()
+
+	abandoned := m.Lock()
+	next := m.Lock()
+	assert.True(t, abandoned.Cancel())
+
+	m.Unlock()
+	_, err := next.Result()
+	assert.NoError(t, err)
+
+	_, err = abandoned.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
+
+func TestMutexUnlockOfUnlockedPanics(t *testing.T) {
+	var m asynclock.Mutex
+	assert.Panics(t, func() { m.Unlock() })
+}
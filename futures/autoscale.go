@@ -0,0 +1,115 @@
+package futures
+
+import "time"
+
+// AutoscaleConfig tunes WithAutoscale.
+type AutoscaleConfig struct {
+	// TargetQueueWait is the queue wait above which the executor allows
+	// more workers.
+	TargetQueueWait time.Duration
+
+	// ScaleDownBelow is the queue wait below which the executor allows
+	// fewer workers. Waits between it and TargetQueueWait change nothing,
+	// so that the pool does not flap. Zero means half of TargetQueueWait.
+	ScaleDownBelow time.Duration
+
+	// Cooldown is the least time between two adjustments. Zero means one
+	// second.
+	Cooldown time.Duration
+
+	// Step is how many workers each adjustment adds or removes. Zero means
+	// one.
+	Step int
+}
+
+// WorkerMetricsCollector is a MetricsCollector that is also told how many
+// workers an executor has, for instance to watch it autoscale. WorkersChanged
+// is called after workers start or retire, with the current count; the calls
+// of one executor do not overlap. Pools report to their own collector, as for
+// the other metrics (see Pool).
+type WorkerMetricsCollector interface {
+	MetricsCollector
+	WorkersChanged(workers int)
+}
+
+// WithAutoscale makes the executor size its pool by queue latency instead of
+// by queue depth alone. The number of workers the executor may run starts at
+// its minimum (see WithMinIdle, and at least one) and grows by cfg.Step, up
+// to the executor's maximum, whenever a task waited longer than
+// cfg.TargetQueueWait for a worker. It shrinks by cfg.Step when tasks wait
+// less than cfg.ScaleDownBelow, and workers above the limit retire as they
+// finish their task; idle workers still retire after the idle timeout.
+// Adjustments are at least cfg.Cooldown apart.
+//
+// Pools do not inherit autoscaling; pass WithAutoscale to WithPool to
+// autoscale a pool.
+func WithAutoscale(cfg AutoscaleConfig) ExecutorOption {
+	if cfg.ScaleDownBelow == 0 {
+		cfg.ScaleDownBelow = cfg.TargetQueueWait / 2
+	}
+	if cfg.Cooldown == 0 {
+		cfg.Cooldown = time.Second
+	}
+	if cfg.Step < 1 {
+		cfg.Step = 1
+	}
+	return func(e *Executor) {
+		e.autoscale = &autoscaler{cfg: cfg}
+	}
+}
+
+// autoscaler holds the state of WithAutoscale, guarded by the executor's
+// mutex.
+type autoscaler struct {
+	cfg      AutoscaleConfig
+	limit    int       // Workers the executor may run at the moment
+	adjusted time.Time // Time of the last adjustment
+}
+
+// maxWorkersLocked returns how many workers may run at the moment.
+func (e *Executor) maxWorkersLocked() int {
+	if e.autoscale == nil {
+		return e.maxWorkers
+	}
+	return e.autoscale.limit
+}
+
+// observeLocked adjusts the autoscaling limit to the queue wait of a task
+// just picked up by a worker, starting workers for the waiting tasks if it
+// grew. It reports whether workers were started.
+func (e *Executor) observeLocked(wait time.Duration, now time.Time) bool {
+	a := e.autoscale
+	if now.Sub(a.adjusted) < a.cfg.Cooldown {
+		return false
+	}
+	floor := max(e.minIdle, 1)
+	switch {
+	case wait > a.cfg.TargetQueueWait && a.limit < e.maxWorkers:
+		a.limit = min(a.limit+a.cfg.Step, e.maxWorkers)
+	case wait < a.cfg.ScaleDownBelow && a.limit > floor:
+		a.limit = max(a.limit-a.cfg.Step, floor)
+	default:
+		return false
+	}
+	a.adjusted = now
+	started := false
+	for e.workers-e.busy < e.waiting && e.workers < a.limit {
+		e.workers++
+		started = true
+		go e.worker()
+	}
+	return started
+}
+
+// reportWorkers passes the current number of workers to a
+// WorkerMetricsCollector. The count is read under reportMu, so that the last
+// call always carries the latest count. It must not be called with e.mu held.
+func (e *Executor) reportWorkers() {
+	c, ok := collectorFor(e).(WorkerMetricsCollector)
+	if !ok {
+		return
+	}
+	e.reportMu.Lock()
+	defer e.reportMu.Unlock()
+	c.WorkersChanged(e.Workers())
+}
@@ -0,0 +1,90 @@
+package futures_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+type workerMetrics struct {
+	recordingMetrics
+	mu      sync.Mutex
+	workers []int
+}
+
+func (m *workerMetrics) WorkersChanged(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.workers = append(m.workers, n)
+}
+
+func (m *workerMetrics) peak() (peak, last int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, n := range m.workers {
+		peak = max(peak, n)
+	}
+	if len(m.workers) > 0 {
+		last = m.workers[len(m.workers)-1]
+	}
+	return peak, last
+}
+
+func TestAutoscaleGrowsOnQueueLatencyAndShrinksWhenIdle(t *testing.T) {
+	m := &workerMetrics{}
+	e := futures.NewExecutor(4, 16, futures.WithMetrics(m), futures.WithAutoscale(futures.AutoscaleConfig{
+		TargetQueueWait: 5 * time.Millisecond,
+		Cooldown:        time.Millisecond,
+	}))
+	defer e.Shutdown(context.Background())
+	assert.Equal(t, 0, e.Workers())
+
+	// A backlog of slow tasks makes the queue wait grow past the target.
+	fs := make([]*futures.Future[int], 12)
+	for i := range fs {
+		fs[i] = futures.Submit(e, func() (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return i, nil
+		})
+	}
+	_, err := futures.All(fs...).Result()
+	assert.NoError(t, err)
+	peak, _ := m.peak()
+	assert.Greater(t, peak, 1)
+	assert.LessOrEqual(t, peak, 4)
+
+	// Tasks picked up right away bring the limit back down, and the extra
+	// workers retire as they finish.
+	for i := 0; i < 20; i++ {
+		_, err := futures.Submit(e, func() (int, error) { return i, nil }).Result()
+		assert.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+	}
+	assert.Eventually(t, func() bool { return e.Workers() == 1 }, time.Second, time.Millisecond)
+	_, last := m.peak()
+	assert.Equal(t, 1, last)
+}
+
+func TestAutoscaleKeepsMinimumBelowTarget(t *testing.T) {
+	e := futures.NewExecutor(4, 16, futures.WithMinIdle(2), futures.WithAutoscale(futures.AutoscaleConfig{
+		TargetQueueWait: time.Hour,
+		Cooldown:        time.Millisecond,
+	}))
+	defer e.Shutdown(context.Background())
+
+	// Waits below the target never add workers, and the minimum is kept.
+	fs := make([]*futures.Future[int], 8)
+	for i := range fs {
+		fs[i] = futures.Submit(e, func() (int, error) {
+			time.Sleep(time.Millisecond)
+			return i, nil
+		})
+	}
+	_, err := futures.All(fs...).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, e.Workers())
+}
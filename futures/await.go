@@ -0,0 +1,71 @@
+package futures
+
+import (
+	"context"
+	"time"
+)
+
+// ResultTimeout is like Result but gives up after d, as measured by the
+// future's clock, returning ErrTimeout. Only the wait is abandoned: the future
+// keeps running and can still be waited for later.
+func (f *Future[T]) ResultTimeout(d time.Duration) (T, error) {
+	f.Start()
+
+	select {
+	case <-f.doneChan():
+		return f.outcome()
+	case <-f.clock.After(d):
+		var zero T
+		return zero, ErrTimeout
+	}
+}
+
+// Await starts f and waits for its result until ctx ends, in which case it
+// returns ctx.Err(). As with ResultTimeout, the future itself keeps running.
+func Await[T any](ctx context.Context, f *Future[T]) (T, error) {
+	f.Start()
+
+	select {
+	case <-f.doneChan():
+		return f.outcome()
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Results starts fs and waits for all of them until ctx ends. It returns
+// their values in input order, with the zero value for inputs that did not
+// succeed. If ctx ends first, the error is a *PendingError listing the inputs
+// still running, and the values of those that already succeeded are kept.
+// Otherwise it is an AggregateError holding the failures, or nil. As with
+// Await, the futures themselves keep running.
+func Results[T any](ctx context.Context, fs ...*Future[T]) ([]T, error) {
+	for _, f := range fs {
+		f.Start()
+	}
+
+	values := make([]T, len(fs))
+	errs := make([]error, len(fs))
+	for i, f := range fs {
+		select {
+		case <-f.doneChan():
+			if v, err := f.outcome(); err != nil {
+				errs[i] = err
+			} else {
+				values[i] = v
+			}
+		case <-ctx.Done():
+			pending := &PendingError{Err: ctx.Err()}
+			for j := i; j < len(fs); j++ {
+				if v, err, ok := fs[j].TryResult(); !ok {
+					pending.Pending = append(pending.Pending, j)
+				} else if err == nil {
+					values[j] = v
+				}
+			}
+			return values, pending
+		}
+	}
+	return values, aggregate(errs)
+}
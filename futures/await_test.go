@@ -0,0 +1,77 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultTimeout(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.NewFuture(func() (string, error) {
+		<-release
+		return "done", nil
+	})
+
+	_, err := f.ResultTimeout(10 * time.Millisecond)
+	assert.ErrorIs(t, err, futures.ErrTimeout)
+
+	// The future is unaffected and can still be waited for.
+	close(release)
+	v, err := f.ResultTimeout(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "done", v)
+}
+
+func TestAwaitHonoursContext(t *testing.T) {
+	f := futures.NewFuture(func() (int, error) {
+		time.Sleep(time.Second)
+		return 1, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := futures.Await(ctx, f)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	quick := futures.NewFuture(func() (int, error) { return 2, nil })
+	v, err := futures.Await(context.Background(), quick)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func TestResultsReturnsPartialResultsWhenContextEnds(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	slow := futures.NewFuture(func() (int, error) {
+		<-release
+		return 2, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	values, err := futures.Results(ctx, futures.Resolved(1), slow, futures.Resolved(3))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	var pending *futures.PendingError
+	assert.ErrorAs(t, err, &pending)
+	assert.Equal(t, []int{1}, pending.Pending)
+	assert.Equal(t, []int{1, 0, 3}, values)
+}
+
+func TestResultsWaitsForAll(t *testing.T) {
+	boom := errors.New("boom")
+	values, err := futures.Results(context.Background(), futures.Resolved(1), futures.Failed[int](boom), futures.Resolved(3))
+	assert.ErrorIs(t, err, boom)
+	var agg *futures.AggregateError
+	assert.ErrorAs(t, err, &agg)
+	assert.Equal(t, []int{1}, agg.Indices)
+	assert.Equal(t, []int{1, 0, 3}, values)
+
+	values, err = futures.Results(context.Background(), futures.Resolved(4), futures.Resolved(5))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 5}, values)
+}
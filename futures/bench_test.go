@@ -0,0 +1,152 @@
+package futures_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+func BenchmarkThenChain(b *testing.B) {
+	const depth = 100
+	b.ReportAllocs()
+	for b.Loop() {
+		f := futures.Resolved(0)
+		for range depth {
+			f = futures.Then(f, func(n int) (int, error) { return n + 1, nil })
+		}
+		if n, _ := f.Result(); n != depth {
+			b.Fatalf("got %d, want %d", n, depth)
+		}
+	}
+}
+
+func BenchmarkThenChainReleased(b *testing.B) {
+	const depth = 100
+	b.ReportAllocs()
+	stages := make([]*futures.Future[int], 0, depth+1)
+	for b.Loop() {
+		f := futures.Resolved(0)
+		stages = append(stages[:0], f)
+		for range depth {
+			f = futures.Then(f, func(n int) (int, error) { return n + 1, nil })
+			stages = append(stages, f)
+		}
+		if n, _ := f.Result(); n != depth {
+			b.Fatalf("got %d, want %d", n, depth)
+		}
+		for _, s := range stages {
+			s.Release()
+		}
+	}
+}
+
+func BenchmarkThenFanOut(b *testing.B) {
+	const width = 1000
+	b.ReportAllocs()
+	for b.Loop() {
+		p := futures.NewPromise[int]()
+		stages := make([]*futures.Future[int], width)
+		for i := range stages {
+			stages[i] = futures.Then(p.Future(), func(n int) (int, error) { return n + i, nil })
+		}
+		p.Complete(1)
+		if _, err := futures.All(stages...).Result(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPromiseRoundTrip(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		p := futures.NewPromise[int]()
+		p.Complete(1)
+		if n, _ := p.Future().Result(); n != 1 {
+			b.Fatalf("got %d, want 1", n)
+		}
+	}
+}
+
+func BenchmarkFutureRoundTrip(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		f := futures.NewFuture(func() (int, error) { return 1, nil })
+		if n, _ := f.Result(); n != 1 {
+			b.Fatalf("got %d, want 1", n)
+		}
+	}
+}
+
+func BenchmarkPollState(b *testing.B) {
+	p := futures.NewPromise[int]()
+	f := p.Future()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if f.IsDone() {
+				b.Fatal("settled unexpectedly")
+			}
+		}
+	})
+}
+
+func BenchmarkPollTryResult(b *testing.B) {
+	f := futures.Resolved(1)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, _, ok := f.TryResult(); !ok {
+				b.Fatal("not settled")
+			}
+		}
+	})
+}
+
+func BenchmarkManyWaiters(b *testing.B) {
+	const waiters = 1000
+	b.ReportAllocs()
+	for b.Loop() {
+		p := futures.NewPromise[int]()
+		f := p.Future()
+		var ready, done sync.WaitGroup
+		ready.Add(waiters)
+		done.Add(waiters)
+		for range waiters {
+			go func() {
+				defer done.Done()
+				ready.Done()
+				if n, _ := f.Result(); n != 1 {
+					b.Errorf("got %d, want 1", n)
+				}
+			}()
+		}
+		ready.Wait()
+		p.Complete(1)
+		done.Wait()
+	}
+}
+
+func BenchmarkResultParallel(b *testing.B) {
+	f := futures.Resolved(1)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if n, _ := f.Result(); n != 1 {
+				b.Fatalf("got %d, want 1", n)
+			}
+		}
+	})
+}
+
+func BenchmarkFanOutFanIn(b *testing.B) {
+	const width = 1000
+	b.ReportAllocs()
+	for b.Loop() {
+		fs := make([]*futures.Future[int], width)
+		for i := range fs {
+			fs[i] = futures.NewFuture(func() (int, error) { return i, nil })
+		}
+		out, err := futures.All(fs...).Result()
+		if err != nil || len(out) != width {
+			b.Fatal(err)
+		}
+	}
+}
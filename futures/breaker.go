@@ -0,0 +1,211 @@
+package futures
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BreakerState is the position of a CircuitBreaker.
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // Calls run normally
+	BreakerOpen                         // Calls fail fast with ErrCircuitOpen
+	BreakerHalfOpen                     // A single trial call decides whether to close
+)
+
+// String returns the name of the state, such as "Open".
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "Closed"
+	case BreakerOpen:
+		return "Open"
+	case BreakerHalfOpen:
+		return "HalfOpen"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// BreakerConfig sets when a CircuitBreaker trips and how long it stays open.
+type BreakerConfig struct {
+	// Threshold is the number of consecutive failures that opens the
+	// breaker. Defaults to 5.
+	Threshold int
+	// FailureRate, if set, also opens the breaker once at least that
+	// fraction (0 to 1) of the last Window calls failed.
+	FailureRate float64
+	// Window is the number of recent calls FailureRate is measured over.
+	// Defaults to 20.
+	Window int
+	// Cooldown is how long the breaker stays open before letting a trial
+	// call through. Defaults to 30 seconds.
+	Cooldown time.Duration
+}
+
+// CircuitBreaker guards calls to a flaky dependency. While closed it runs
+// every task and watches the outcomes; after too many failures it opens and
+// rejects calls with ErrCircuitOpen without running them, giving the
+// dependency time to recover. Once the cooldown has passed it half-opens and
+// lets one trial call through: success closes it again, failure reopens it.
+//
+// Cancelled calls are not counted either way.
+type CircuitBreaker[T any] struct {
+	cfg   BreakerConfig
+	clock Clock
+	token *CancelToken
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int    // consecutive failures while closed
+	recent   []bool // outcomes of the last Window calls, true for failure
+	pos      int    // next slot of recent to overwrite once it is full
+	openedAt time.Time
+	trial    bool // a half-open trial call is in flight
+}
+
+// NewCircuitBreaker creates a closed breaker. Pass WithClock to measure the
+// cooldown on another clock.
+func NewCircuitBreaker[T any](cfg BreakerConfig, opts ...Option) *CircuitBreaker[T] {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = 5
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 20
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = 30 * time.Second
+	}
+	o := buildOptions(opts)
+	return &CircuitBreaker[T]{cfg: cfg, clock: o.clock, token: o.token}
+}
+
+// Do starts task and returns its future, or returns a future rejected with
+// ErrCircuitOpen if the breaker is not letting calls through.
+func (b *CircuitBreaker[T]) Do(task func() (T, error)) *Future[T] {
+	trial, ok := b.allow()
+	if !ok {
+		return Failed[T](ErrCircuitOpen)
+	}
+
+	f := NewFuture(task, WithCancelToken(b.token))
+	f.OnComplete(func(_ T, err error) {
+		b.record(trial, err)
+	})
+	f.Start()
+	return f
+}
+
+// State returns the breaker's current position. An open breaker whose
+// cooldown has passed reports BreakerHalfOpen.
+func (b *CircuitBreaker[T]) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && b.cooledDownLocked() {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Reset closes the breaker and forgets past failures.
+func (b *CircuitBreaker[T]) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closeLocked()
+}
+
+// allow reports whether a call may run, and whether it is the trial call of
+// a half-open breaker.
+func (b *CircuitBreaker[T]) allow() (trial, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.cooledDownLocked() {
+		b.state = BreakerHalfOpen
+	}
+	switch b.state {
+	case BreakerClosed:
+		return false, true
+	case BreakerHalfOpen:
+		if b.trial {
+			return false, false
+		}
+		b.trial = true
+		return true, true
+	}
+	return false, false
+}
+
+func (b *CircuitBreaker[T]) record(trial bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if trial {
+		b.trial = false
+	}
+	if errors.Is(err, ErrCancelled) {
+		return
+	}
+	if trial {
+		if err != nil {
+			b.openLocked()
+		} else {
+			b.closeLocked()
+		}
+		return
+	}
+	if b.state != BreakerClosed {
+		// A call admitted before the breaker tripped.
+		return
+	}
+
+	failed := err != nil
+	if failed {
+		b.failures++
+	} else {
+		b.failures = 0
+	}
+	if len(b.recent) < b.cfg.Window {
+		b.recent = append(b.recent, failed)
+	} else {
+		b.recent[b.pos] = failed
+		b.pos = (b.pos + 1) % b.cfg.Window
+	}
+
+	if b.failures >= b.cfg.Threshold || b.rateExceededLocked() {
+		b.openLocked()
+	}
+}
+
+func (b *CircuitBreaker[T]) rateExceededLocked() bool {
+	if b.cfg.FailureRate <= 0 || len(b.recent) < b.cfg.Window {
+		return false
+	}
+	n := 0
+	for _, failed := range b.recent {
+		if failed {
+			n++
+		}
+	}
+	return float64(n) >= b.cfg.FailureRate*float64(len(b.recent))
+}
+
+func (b *CircuitBreaker[T]) cooledDownLocked() bool {
+	return !b.clock.Now().Before(b.openedAt.Add(b.cfg.Cooldown))
+}
+
+func (b *CircuitBreaker[T]) openLocked() {
+	b.state = BreakerOpen
+	b.openedAt = b.clock.Now()
+}
+
+func (b *CircuitBreaker[T]) closeLocked() {
+	b.state = BreakerClosed
+	b.trial = false
+	b.failures = 0
+	b.recent = b.recent[:0]
+	b.pos = 0
+}
@@ -0,0 +1,69 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/futurestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	clock := futurestest.NewClock(time.Now())
+	b := futures.NewCircuitBreaker[int](futures.BreakerConfig{Threshold: 2, Cooldown: time.Minute}, futures.WithClock(clock))
+	boom := errors.New("boom")
+	calls := 0
+	fail := func() (int, error) { calls++; return 0, boom }
+	succeed := func() (int, error) { calls++; return 1, nil }
+
+	for range 2 {
+		_, err := b.Do(fail).Result()
+		assert.ErrorIs(t, err, boom)
+	}
+	assert.Equal(t, futures.BreakerOpen, b.State())
+
+	_, err := b.Do(succeed).Result()
+	assert.ErrorIs(t, err, futures.ErrCircuitOpen)
+	assert.Equal(t, 2, calls)
+
+	// A failed trial reopens the breaker for another cooldown.
+	clock.Advance(time.Minute)
+	assert.Equal(t, futures.BreakerHalfOpen, b.State())
+	_, err = b.Do(fail).Result()
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, futures.BreakerOpen, b.State())
+
+	clock.Advance(time.Minute)
+	v, err := b.Do(succeed).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, futures.BreakerClosed, b.State())
+}
+
+func TestCircuitBreakerHalfOpenAdmitsOneTrial(t *testing.T) {
+	clock := futurestest.NewClock(time.Now())
+	b := futures.NewCircuitBreaker[int](futures.BreakerConfig{Threshold: 1, Cooldown: time.Second}, futures.WithClock(clock))
+	b.Do(func() (int, error) { return 0, errors.New("down") }).Result()
+
+	clock.Advance(time.Second)
+	release := make(chan struct{})
+	trial := b.Do(func() (int, error) { <-release; return 1, nil })
+	_, err := b.Do(func() (int, error) { return 2, nil }).Result()
+	assert.ErrorIs(t, err, futures.ErrCircuitOpen)
+
+	close(release)
+	_, err = trial.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, futures.BreakerClosed, b.State())
+}
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	b := futures.NewCircuitBreaker[int](futures.BreakerConfig{Threshold: 100, FailureRate: 0.5, Window: 4})
+	outcomes := []error{nil, errors.New("a"), nil, errors.New("b")}
+	for _, err := range outcomes {
+		b.Do(func() (int, error) { return 0, err }).Result()
+	}
+	assert.Equal(t, futures.BreakerOpen, b.State())
+}
@@ -0,0 +1,179 @@
+package futures
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// CacheConfig tunes a Cache. The zero value caches successful results until
+// they are evicted or invalidated and never caches failures.
+type CacheConfig struct {
+	// TTL is how long a result stays fresh. Zero keeps it fresh forever.
+	TTL time.Duration
+	// StaleWhileRevalidate is how long after TTL an expired result is still
+	// served while a single background refresh replaces it. Zero expires
+	// results as soon as TTL has passed.
+	StaleWhileRevalidate time.Duration
+	// FailureTTL is how long failures are cached. Zero, the default, never
+	// caches them, so the next call runs the task again.
+	FailureTTL time.Duration
+	// MaxEntries bounds the cache, evicting the least recently used entries
+	// beyond it. Zero means unbounded.
+	MaxEntries int
+}
+
+// Cache memoizes keyed computations as futures. Like SingleFlight, concurrent
+// calls for the same key share one future; on top of that, settled results
+// expire, can be refreshed in the background while the stale value is still
+// served, and are evicted in least recently used order once the cache is
+// full. Cancelled futures are never cached.
+//
+// Callers share the returned futures, so cancelling one cancels it for all of
+// them.
+type Cache[K comparable, V any] struct {
+	cfg   CacheConfig
+	clock Clock
+	token *CancelToken
+
+	mu      sync.Mutex
+	entries map[K]*list.Element // of *cacheEntry
+	lru     *list.List          // most recently used first
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key        K
+	future     *Future[V]
+	expires    time.Time // Zero while in flight, or if the result never expires
+	refreshing bool
+}
+
+// NewCache creates an empty cache configured by cfg. Pass WithClock to
+// control how expiry is measured.
+func NewCache[K comparable, V any](cfg CacheConfig, opts ...Option) *Cache[K, V] {
+	o := buildOptions(opts)
+	return &Cache[K, V]{
+		cfg:     cfg,
+		clock:   o.clock,
+		token:   o.token,
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// GetOrCompute returns the in-flight or cached future for key, or starts task
+// and returns its future if there is none. A result past its TTL but within
+// the stale-while-revalidate window is returned as is while task refreshes
+// it in the background.
+func (c *Cache[K, V]) GetOrCompute(key K, task func() (V, error)) *Future[V] {
+	c.mu.Lock()
+	now := c.clock.Now()
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*cacheEntry[K, V])
+		switch fresh, stale := c.usable(e, now); {
+		case fresh:
+			c.lru.MoveToFront(el)
+			c.mu.Unlock()
+			return e.future
+		case stale:
+			c.lru.MoveToFront(el)
+			f := e.future
+			refresh := !e.refreshing
+			e.refreshing = true
+			c.mu.Unlock()
+			if refresh {
+				c.refresh(e, task)
+			}
+			return f
+		}
+		c.removeLocked(el)
+	}
+
+	e := &cacheEntry[K, V]{key: key, future: NewFuture(task, WithClock(c.clock), WithCancelToken(c.token))}
+	c.entries[key] = c.lru.PushFront(e)
+	for c.cfg.MaxEntries > 0 && c.lru.Len() > c.cfg.MaxEntries {
+		c.removeLocked(c.lru.Back())
+	}
+	c.mu.Unlock()
+
+	f := e.future
+	f.OnComplete(func(_ V, err error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if el, ok := c.entries[key]; !ok || el.Value != e {
+			// Evicted or invalidated while in flight
+			return
+		}
+		switch {
+		case err == nil && c.cfg.TTL > 0:
+			e.expires = c.clock.Now().Add(c.cfg.TTL)
+		case err != nil && f.State() != Cancelled && c.cfg.FailureTTL > 0:
+			e.expires = c.clock.Now().Add(c.cfg.FailureTTL)
+		case err != nil:
+			c.removeLocked(c.entries[key])
+		}
+	})
+	f.Start()
+	return f
+}
+
+// refresh runs task in the background and swaps its result into e if it
+// succeeds; a failed refresh leaves the stale result in place.
+func (c *Cache[K, V]) refresh(e *cacheEntry[K, V], task func() (V, error)) {
+	next := NewFuture(task, WithClock(c.clock), WithCancelToken(c.token))
+	next.OnComplete(func(_ V, err error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		e.refreshing = false
+		if err != nil {
+			return
+		}
+		e.future = next
+		e.expires = time.Time{}
+		if c.cfg.TTL > 0 {
+			e.expires = c.clock.Now().Add(c.cfg.TTL)
+		}
+	})
+	next.Start()
+}
+
+// usable reports whether e may be handed out at now, either as fresh or as a
+// stale result due for a refresh.
+func (c *Cache[K, V]) usable(e *cacheEntry[K, V], now time.Time) (fresh, stale bool) {
+	state := e.future.State()
+	switch {
+	case state == Cancelled:
+		return false, false
+	case state == Rejected && e.expires.IsZero():
+		// A failure that is not cached, or not yet recorded as cached
+		return false, false
+	case e.expires.IsZero() || now.Before(e.expires):
+		return true, false
+	case state == Fulfilled && now.Before(e.expires.Add(c.cfg.StaleWhileRevalidate)):
+		return false, true
+	}
+	return false, false
+}
+
+// Invalidate drops the entry for key, so the next GetOrCompute runs its task
+// again. Callers already holding its future still receive the result.
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.removeLocked(el)
+	}
+}
+
+// Len returns the number of entries, including expired ones not yet
+// replaced.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *Cache[K, V]) removeLocked(el *list.Element) {
+	e := c.lru.Remove(el).(*cacheEntry[K, V])
+	delete(c.entries, e.key)
+}
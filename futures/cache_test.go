@@ -0,0 +1,113 @@
+package futures_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheExpiresAfterTTL(t *testing.T) {
+	clock := &nowClock{}
+	clock.set(time.Unix(0, 0))
+	c := futures.NewCache[string, int](futures.CacheConfig{TTL: time.Minute}, futures.WithClock(clock))
+
+	var calls atomic.Int32
+	task := func() (int, error) { return int(calls.Add(1)), nil }
+
+	first := c.GetOrCompute("k", task)
+	v, err := first.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.Same(t, first, c.GetOrCompute("k", task))
+
+	clock.set(time.Unix(61, 0))
+	v, err = c.GetOrCompute("k", task).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func TestCacheServesStaleWhileRevalidating(t *testing.T) {
+	clock := &nowClock{}
+	clock.set(time.Unix(0, 0))
+	c := futures.NewCache[string, int](futures.CacheConfig{
+		TTL:                  time.Minute,
+		StaleWhileRevalidate: time.Minute,
+	}, futures.WithClock(clock))
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	task := func() (int, error) {
+		n := calls.Add(1)
+		if n > 1 {
+			<-release
+		}
+		return int(n), nil
+	}
+	_, _ = c.GetOrCompute("k", task).Result()
+
+	clock.set(time.Unix(90, 0))
+	v, err := c.GetOrCompute("k", task).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v, "stale value is served during the refresh")
+	v, _ = c.GetOrCompute("k", task).Result()
+	assert.Equal(t, 1, v)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		v, _ := c.GetOrCompute("k", task).Result()
+		return v == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), calls.Load(), "only one refresh runs")
+}
+
+func TestCacheFailures(t *testing.T) {
+	clock := &nowClock{}
+	clock.set(time.Unix(0, 0))
+	var calls atomic.Int32
+	failing := func() (int, error) {
+		calls.Add(1)
+		return 0, fmt.Errorf("backend down")
+	}
+
+	c := futures.NewCache[string, int](futures.CacheConfig{}, futures.WithClock(clock))
+	_, err := c.GetOrCompute("k", failing).Result()
+	assert.EqualError(t, err, "backend down")
+	assert.Eventually(t, func() bool { return c.Len() == 0 }, time.Second, time.Millisecond)
+	_, _ = c.GetOrCompute("k", failing).Result()
+	assert.Equal(t, int32(2), calls.Load())
+
+	calls.Store(0)
+	negative := futures.NewCache[string, int](futures.CacheConfig{FailureTTL: time.Second}, futures.WithClock(clock))
+	first := negative.GetOrCompute("k", failing)
+	_, _ = first.Result()
+	assert.Eventually(t, func() bool { return negative.GetOrCompute("k", failing) == first }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), calls.Load())
+
+	clock.set(time.Unix(2, 0))
+	assert.NotSame(t, first, negative.GetOrCompute("k", failing))
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := futures.NewCache[string, string](futures.CacheConfig{MaxEntries: 2})
+	compute := func(v string) func() (string, error) {
+		return func() (string, error) { return v, nil }
+	}
+
+	a := c.GetOrCompute("a", compute("a"))
+	c.GetOrCompute("b", compute("b"))
+	assert.Same(t, a, c.GetOrCompute("a", compute("a")))
+	c.GetOrCompute("c", compute("c")) // evicts b
+
+	assert.Equal(t, 2, c.Len())
+	assert.Same(t, a, c.GetOrCompute("a", compute("a")))
+	v, err := c.GetOrCompute("b", compute("b again")).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "b again", v)
+
+	c.Invalidate("a")
+	assert.NotSame(t, a, c.GetOrCompute("a", compute("a")))
+}
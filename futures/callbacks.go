@@ -1,29 +1,149 @@
 package futures
 
+// Subscription is returned when a callback is registered and lets the caller
+// remove it again, so that long-lived futures do not keep callbacks alive on
+// behalf of callers that have gone away.
+type Subscription struct {
+	unsubscribe func()
+}
+
+// Unsubscribe removes the callback so that it will not run. It has no effect
+// if the callback has already run or been removed.
+func (s *Subscription) Unsubscribe() {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+}
+
+// subscription returns a Subscription calling remove under the lock.
+func (f *Future[T]) subscription(remove func()) *Subscription {
+	return &Subscription{unsubscribe: func() {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		remove()
+	}}
+}
+
+// unset clears the callback at index i of list, unless the list has since been
+// released by the future settling.
+func unset[F any](list []F, i int) {
+	if i < len(list) {
+		var zero F
+		list[i] = zero
+	}
+}
+
 // OnSuccess registers a callback function to be called when the future completes successfully.
-func (f *Future[T]) OnSuccess(cb func(T)) {
+//
+// Callbacks run at most once each, one at a time and in the order they were
+// registered, on the goroutine that settles the future. A callback registered
+// once the future has settled is queued behind those still running, and runs
+// asynchronously on the future's executor, or on a goroutine of its own for
+// futures without one. The same holds for OnFailure, OnCancel and OnComplete.
+func (f *Future[T]) OnSuccess(cb func(T)) *Subscription {
 	f.mutex.Lock()
-	defer f.mutex.Unlock()
 
-	// If the future is already fulfilled, execute the callback immediately
-	if f.state == Fulfilled {
-		cb(f.result)
-		return
+	// If the future is already settled, queue the callback behind the others
+	if state := f.state.Load(); state.settled() {
+		res := f.result
+		f.mutex.Unlock()
+		if state == Fulfilled {
+			f.schedule(func() { cb(res) })
+		}
+		return &Subscription{}
 	}
+	defer f.mutex.Unlock()
 
+	i := len(f.onSuccess)
 	f.onSuccess = append(f.onSuccess, cb)
+	return f.subscription(func() { unset(f.onSuccess, i) })
 }
 
 // OnFailure registers a callback function to be called when the future completes with an error.
-func (f *Future[T]) OnFailure(cb func(error)) {
+// A cancelled future counts as failed, with ErrCancelled as its error.
+func (f *Future[T]) OnFailure(cb func(error)) *Subscription {
 	f.mutex.Lock()
-	defer f.mutex.Unlock()
 
-	// If the future is already rejected, execute the callback immediately
-	if f.state == Rejected {
-		cb(f.err)
-		return
+	// If the future is already settled, queue the callback behind the others
+	if state := f.state.Load(); state.settled() {
+		err := f.err
+		f.mutex.Unlock()
+		if state != Fulfilled {
+			f.schedule(func() { cb(err) })
+		}
+		return &Subscription{}
 	}
+	defer f.mutex.Unlock()
 
+	i := len(f.onFailure)
 	f.onFailure = append(f.onFailure, cb)
+	return f.subscription(func() { unset(f.onFailure, i) })
+}
+
+// OnCancel registers a callback to be called if the future is cancelled
+// before settling, so that resources held for its task (temporary files,
+// leases, connections) can be released. It runs for Cancel, for cancellation
+// propagated from downstream or upstream and for the cancellation of the
+// context given to NewFutureCtx, before any OnFailure callbacks; it does not
+// run for timeouts or ordinary failures.
+func (f *Future[T]) OnCancel(cb func()) *Subscription {
+	f.mutex.Lock()
+
+	// If the future is already settled, queue the callback behind the others
+	if state := f.state.Load(); state.settled() {
+		f.mutex.Unlock()
+		if state == Cancelled {
+			f.schedule(cb)
+		}
+		return &Subscription{}
+	}
+	defer f.mutex.Unlock()
+
+	i := len(f.onCancel)
+	f.onCancel = append(f.onCancel, cb)
+	return f.subscription(func() { unset(f.onCancel, i) })
+}
+
+// OnComplete registers a callback function to be called exactly once when the future settles,
+// whether it succeeded, failed or was cancelled. It receives the result and the error.
+func (f *Future[T]) OnComplete(cb func(T, error)) *Subscription {
+	f.mutex.Lock()
+
+	// If the future is already settled, queue the callback behind the others
+	if f.state.Load().settled() {
+		res, err := f.result, f.err
+		f.mutex.Unlock()
+		f.schedule(func() { cb(res, err) })
+		return &Subscription{}
+	}
+	defer f.mutex.Unlock()
+
+	i := len(f.onDone)
+	f.onDone = append(f.onDone, cb)
+	return f.subscription(func() { unset(f.onDone, i) })
+}
+
+// Finally registers cleanup logic (closing files, releasing locks) to run once the future
+// settles, regardless of the outcome.
+func (f *Future[T]) Finally(cb func()) *Subscription {
+	return f.OnComplete(func(T, error) {
+		cb()
+	})
+}
+
+// OnStateChange registers a callback function to be called on every later
+// state transition of the future, such as Pending to Running and Running to
+// Fulfilled, with the old and the new state.
+func (f *Future[T]) OnStateChange(cb func(old, new State)) *Subscription {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	// A settled future has no transitions left
+	if f.state.Load().settled() {
+		return &Subscription{}
+	}
+
+	i := len(f.onState)
+	f.onState = append(f.onState, cb)
+	return f.subscription(func() { unset(f.onState, i) })
 }
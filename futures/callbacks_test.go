@@ -0,0 +1,121 @@
+package futures_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnCompleteRunsForEveryOutcome(t *testing.T) {
+	boom := errors.New("boom")
+	outcomes := map[string]*futures.Future[int]{
+		"success": futures.NewFuture(func() (int, error) { return 1, nil }),
+		"failure": futures.NewFuture(func() (int, error) { return 0, boom }),
+	}
+
+	for name, f := range outcomes {
+		var calls, cleanups int
+		var gotErr error
+		f.OnComplete(func(_ int, err error) {
+			calls++
+			gotErr = err
+		})
+		f.Finally(func() { cleanups++ })
+
+		_, err := f.Result()
+		assert.Equal(t, 1, calls, name)
+		assert.Equal(t, 1, cleanups, name)
+		assert.Equal(t, err, gotErr, name)
+	}
+
+	cancelled := futures.NewPromise[int]().Future()
+	var ran bool
+	cancelled.Finally(func() { ran = true })
+	cancelled.Cancel()
+	assert.True(t, ran)
+}
+
+func TestUnsubscribedCallbackDoesNotRun(t *testing.T) {
+	p := futures.NewPromise[int]()
+	f := p.Future()
+
+	var removed, kept bool
+	sub := f.OnSuccess(func(int) { removed = true })
+	f.OnSuccess(func(int) { kept = true })
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+
+	p.Complete(1)
+	assert.False(t, removed)
+	assert.True(t, kept)
+
+	// Unsubscribing once settled is harmless.
+	f.OnComplete(func(int, error) {}).Unsubscribe()
+}
+
+func TestOnStateChangeAndTimestamps(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.NewFuture(func() (int, error) {
+		<-release
+		return 1, nil
+	})
+
+	var mu sync.Mutex
+	var transitions [][2]futures.State
+	f.OnStateChange(func(old, new futures.State) {
+		mu.Lock()
+		defer mu.Unlock()
+		transitions = append(transitions, [2]futures.State{old, new})
+	})
+	assert.False(t, f.CreatedAt().IsZero())
+	assert.Zero(t, f.Duration())
+
+	f.Start()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	_, _ = f.Result()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, [][2]futures.State{
+		{futures.Pending, futures.Running},
+		{futures.Running, futures.Fulfilled},
+	}, transitions)
+	assert.False(t, f.StartedAt().Before(f.CreatedAt()))
+	assert.Equal(t, f.SettledAt().Sub(f.StartedAt()), f.Duration())
+	assert.GreaterOrEqual(t, f.Duration(), 10*time.Millisecond)
+}
+
+func TestLateCallbacksRunAsynchronouslyInOrder(t *testing.T) {
+	f := futures.Resolved(1)
+
+	var mu sync.Mutex
+	var order []int
+	for i := range 100 {
+		f.OnSuccess(func(int) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, i)
+		})
+	}
+
+	// A late callback does not hold the future's lock, so it may register
+	// more callbacks; they queue behind it.
+	nested := make(chan struct{})
+	f.OnComplete(func(int, error) {
+		f.Finally(func() { close(nested) })
+	})
+	<-nested
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := make([]int, 100)
+	for i := range want {
+		want[i] = i
+	}
+	assert.Equal(t, want, order)
+}
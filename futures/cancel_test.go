@@ -0,0 +1,139 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelMovesToCancelledState(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.NewFuture(func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	f.Start()
+
+	var cancelled bool
+	var failure error
+	f.OnCancel(func() { cancelled = true })
+	f.OnFailure(func(err error) { failure = err })
+
+	assert.True(t, f.Cancel())
+	assert.False(t, f.Cancel())
+	assert.Equal(t, futures.Cancelled, f.State())
+	assert.True(t, cancelled)
+	assert.ErrorIs(t, failure, futures.ErrCancelled)
+
+	// Late registrations still run, asynchronously.
+	late := make(chan struct{})
+	f.OnCancel(func() { close(late) })
+	<-late
+}
+
+func TestCancelPropagatesDownTheChain(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	root := futures.NewFuture(func() (any, error) {
+		<-release
+		return "never", nil
+	})
+	child := root.Then(func(v any) (any, error) {
+		t.Error("must not run")
+		return v, nil
+	})
+
+	root.Cancel()
+	_, err := child.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	assert.Equal(t, futures.Cancelled, child.State())
+}
+
+func TestCancelAfterCompletionIsNoop(t *testing.T) {
+	f := futures.NewFuture(func() (int, error) { return 7, nil })
+	v, err := f.Result()
+	assert.NoError(t, err)
+
+	assert.False(t, f.Cancel())
+	assert.Equal(t, futures.Fulfilled, f.State())
+	assert.Equal(t, 7, v)
+}
+
+func TestCancelPropagatesUpToUnsharedParent(t *testing.T) {
+	root := futures.NewFutureCtx(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	child := futures.Then(root, func(v int) (int, error) { return v, nil })
+
+	child.Cancel()
+	_, err := root.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
+
+func TestCancelKeepsParentWithOtherConsumers(t *testing.T) {
+	release := make(chan struct{})
+	root := futures.NewFuture(func() (int, error) { <-release; return 1, nil })
+	left := futures.Then(root, func(v int) (int, error) { return v, nil })
+	right := futures.Then(root, func(v int) (int, error) { return v + 1, nil })
+
+	left.Cancel()
+	assert.False(t, root.IsDone())
+
+	close(release)
+	v, err := right.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func TestCancellingAllCancelsItsInputs(t *testing.T) {
+	root := futures.NewFutureCtx(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	first := futures.Then(root, func(v int) (int, error) { return v, nil })
+	all := futures.All(first, futures.Resolved(2))
+	slow := futures.Then(all, func(vs []int) (int, error) { return len(vs), nil })
+
+	all.Cancel()
+	_, err := root.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	assert.Equal(t, futures.Cancelled, first.State())
+	_, err = slow.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
+
+func TestStageTimeoutCancelsUpstream(t *testing.T) {
+	slow := futures.NewFutureCtx(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	joined := futures.AllJoined(slow, futures.Resolved(1)).WithTimeout(10 * time.Millisecond)
+
+	_, err := joined.Result()
+	assert.ErrorIs(t, err, futures.ErrStageTimeout)
+	_, err = slow.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
+
+func TestCancelSparesParentBeingWaitedFor(t *testing.T) {
+	release := make(chan struct{})
+	root := futures.NewFuture(func() (int, error) { <-release; return 1, nil })
+	child := futures.Then(root, func(v int) (int, error) { return v, nil })
+
+	got := make(chan error)
+	go func() {
+		_, err := root.Result()
+		got <- err
+	}()
+	assert.Eventually(t, func() bool { return root.State() == futures.Running }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond) // let the waiter block in Result
+
+	child.Cancel()
+	close(release)
+	assert.NoError(t, <-got)
+}
@@ -0,0 +1,36 @@
+package futures
+
+// FromChannel returns a started future resolving with the first value
+// received from ch. It rejects with ErrChannelClosed if ch is closed first.
+// Cancelling the future stops it from receiving.
+func FromChannel[T any](ch <-chan T) *Future[T] {
+	var f *Future[T]
+	f = NewFuture(func() (T, error) {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return v, ErrChannelClosed
+			}
+			return v, nil
+		case <-f.doneChan():
+			var zero T
+			return zero, ErrCancelled
+		}
+	})
+	f.Start()
+	return f
+}
+
+// ToChannel starts f and returns a channel that receives its outcome once it
+// settles and is then closed. The channel is buffered, so the future never
+// waits for a reader; this lets futures take part in select statements.
+func (f *Future[T]) ToChannel() <-chan Result[T] {
+	ch := make(chan Result[T], 1)
+	f.Start()
+	f.whenSettled(func() {
+		v, err := f.outcome()
+		ch <- Result[T]{Value: v, Err: err}
+		close(ch)
+	})
+	return ch
+}
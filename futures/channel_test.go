@@ -0,0 +1,47 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromChannelTakesFirstValue(t *testing.T) {
+	ch := make(chan string, 2)
+	ch <- "first"
+	ch <- "second"
+
+	v, err := futures.FromChannel(ch).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", v)
+
+	closed := make(chan int)
+	close(closed)
+	_, err = futures.FromChannel(closed).Result()
+	assert.ErrorIs(t, err, futures.ErrChannelClosed)
+}
+
+func TestToChannelWorksWithSelect(t *testing.T) {
+	f := futures.NewFuture(func() (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 0, errors.New("boom")
+	})
+
+	select {
+	case out := <-f.ToChannel():
+		assert.EqualError(t, out.Err, "boom")
+	case <-time.After(time.Second):
+		t.Fatal("future never settled")
+	}
+
+	// The channel is closed after delivering the outcome.
+	ch := futures.NewFuture(func() (int, error) { return 1, nil }).ToChannel()
+	var got []futures.Settled[int]
+	for out := range ch {
+		got = append(got, out)
+	}
+	assert.Equal(t, []futures.Settled[int]{{Value: 1}}, got)
+}
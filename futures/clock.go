@@ -0,0 +1,57 @@
+package futures
+
+import "time"
+
+// Clock abstracts the passage of time so that time-based helpers can be
+// driven by a fake clock in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+	// AfterFunc calls f in its own goroutine once d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending call scheduled with Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the call from happening, reporting false if it already
+	// happened or was stopped.
+	Stop() bool
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// WithExecutorClock makes the futures of an executor, and stages chained from
+// them, take their timestamps and deadlines from c instead of SystemClock.
+func WithExecutorClock(c Clock) ExecutorOption {
+	return func(e *Executor) {
+		e.clock = c
+	}
+}
+
+// clockFor returns the clock for a future created with o and running on exec.
+func clockFor(exec *Executor, o options) Clock {
+	switch {
+	case o.clock != nil:
+		return o.clock
+	case exec != nil && exec.clock != nil:
+		return exec.clock
+	}
+	return SystemClock
+}
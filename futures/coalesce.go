@@ -0,0 +1,143 @@
+package futures
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchFunc resolves many keys with a single upstream call. Keys absent from
+// the returned map are reported to their callers as ErrMissingKey.
+type BatchFunc[K comparable, V any] func(keys []K) (map[K]V, error)
+
+// Coalescer collects keyed requests arriving within a short window and serves
+// them with one call to its BatchFunc. Concurrent requests for the same key
+// within a window share a future.
+type Coalescer[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	window   time.Duration
+	maxBatch int
+	clock    Clock
+	token    *CancelToken
+
+	mu      sync.Mutex
+	pending map[K]*Promise[V]
+	keys    []K
+	timer   Timer
+}
+
+// Batcher is the DataLoader-style name for a Coalescer: individual Load calls
+// are collected and served by one batch call.
+type Batcher[K comparable, V any] = Coalescer[K, V]
+
+// NewCoalescer creates a coalescer that flushes a batch window after the
+// first request arrives, or as soon as maxBatch distinct keys are queued. A
+// maxBatch of zero or less means no size limit.
+func NewCoalescer[K comparable, V any](fetch BatchFunc[K, V], window time.Duration, maxBatch int, opts ...Option) *Coalescer[K, V] {
+	o := buildOptions(opts)
+	return &Coalescer[K, V]{
+		fetch:    fetch,
+		window:   window,
+		maxBatch: maxBatch,
+		clock:    o.clock,
+		token:    o.token,
+		pending:  make(map[K]*Promise[V]),
+	}
+}
+
+// Load returns a future for the value of key, resolved from the next batch.
+func (c *Coalescer[K, V]) Load(key K) *Future[V] {
+	c.mu.Lock()
+	if p, ok := c.pending[key]; ok {
+		c.mu.Unlock()
+		return p.Future()
+	}
+
+	p := NewPromise[V]()
+	attachToken(c.token, p.future)
+	c.pending[key] = p
+	c.keys = append(c.keys, key)
+
+	if c.maxBatch > 0 && len(c.keys) >= c.maxBatch {
+		batch := c.takeLocked()
+		c.mu.Unlock()
+		go c.run(batch)
+		return p.Future()
+	}
+	if c.timer == nil {
+		c.timer = c.clock.AfterFunc(c.window, c.Flush)
+	}
+	c.mu.Unlock()
+	return p.Future()
+}
+
+// NewBatcher is NewCoalescer for code written against the Batcher name.
+func NewBatcher[K comparable, V any](fetch BatchFunc[K, V], window time.Duration, maxBatch int, opts ...Option) *Batcher[K, V] {
+	return NewCoalescer(fetch, window, maxBatch, opts...)
+}
+
+// LoadMany returns a future for the values of keys, in order, which fails
+// with the first key's error if any key fails. The keys join the same batch
+// as other loads, and a failure does not cancel the futures they share with
+// other callers.
+func (c *Coalescer[K, V]) LoadMany(keys ...K) *Future[[]V] {
+	fs := make([]*Future[V], len(keys))
+	for i, key := range keys {
+		fs[i] = c.Load(key)
+	}
+	return Then(AllSettled(fs...), func(outcomes []Result[V]) ([]V, error) {
+		values := make([]V, len(outcomes))
+		for i, o := range outcomes {
+			if o.Err != nil {
+				return nil, o.Err
+			}
+			values[i] = o.Value
+		}
+		return values, nil
+	})
+}
+
+// Flush dispatches the current batch immediately instead of waiting for the
+// window to close.
+func (c *Coalescer[K, V]) Flush() {
+	c.mu.Lock()
+	batch := c.takeLocked()
+	c.mu.Unlock()
+
+	if len(batch.keys) > 0 {
+		c.run(batch)
+	}
+}
+
+type coalescedBatch[K comparable, V any] struct {
+	keys     []K
+	promises map[K]*Promise[V]
+}
+
+// takeLocked detaches the queued requests so that new ones start a new batch.
+func (c *Coalescer[K, V]) takeLocked() coalescedBatch[K, V] {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	batch := coalescedBatch[K, V]{keys: c.keys, promises: c.pending}
+	c.keys = nil
+	c.pending = make(map[K]*Promise[V])
+	return batch
+}
+
+func (c *Coalescer[K, V]) run(batch coalescedBatch[K, V]) {
+	values, err := c.fetch(batch.keys)
+	for _, key := range batch.keys {
+		p := batch.promises[key]
+		if err != nil {
+			p.Fail(err)
+			continue
+		}
+		if v, ok := values[key]; ok {
+			p.Complete(v)
+		} else {
+			p.Fail(fmt.Errorf("%w: %v", ErrMissingKey, key))
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package futures_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalescerBatchesWithinWindow(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]int
+	c := futures.NewCoalescer(func(keys []int) (map[int]string, error) {
+		mu.Lock()
+		batches = append(batches, keys)
+		mu.Unlock()
+
+		out := make(map[int]string)
+		for _, k := range keys {
+			if k != 3 {
+				out[k] = string(rune('a' + k))
+			}
+		}
+		return out, nil
+	}, 20*time.Millisecond, 0)
+
+	one := c.Load(1)
+	two := c.Load(2)
+	again := c.Load(1)
+	missing := c.Load(3)
+	assert.Same(t, one, again)
+
+	v, err := two.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "c", v)
+
+	v, err = one.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "b", v)
+
+	_, err = missing.Result()
+	assert.ErrorIs(t, err, futures.ErrMissingKey)
+
+	assert.Equal(t, [][]int{{1, 2, 3}}, batches)
+}
+
+func TestCoalescerFlushesAtMaxBatch(t *testing.T) {
+	c := futures.NewCoalescer(func(keys []int) (map[int]int, error) {
+		out := make(map[int]int)
+		for _, k := range keys {
+			out[k] = k * 10
+		}
+		return out, nil
+	}, time.Hour, 2)
+
+	a := c.Load(1)
+	b := c.Load(2)
+
+	// The hour-long window is never waited for once the batch is full.
+	va, _ := a.Result()
+	vb, _ := b.Result()
+	assert.Equal(t, 10, va)
+	assert.Equal(t, 20, vb)
+}
+
+func TestBatcherLoadMany(t *testing.T) {
+	var calls int
+	b := futures.NewBatcher(func(keys []string) (map[string]int, error) {
+		calls++
+		return map[string]int{"a": 1, "b": 2}, nil
+	}, 10*time.Millisecond, 0)
+
+	many := b.LoadMany("b", "a")
+	single := b.Load("a")
+
+	vs, err := many.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, vs)
+	v, _ := single.Result()
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 1, calls)
+
+	// A missing key fails the whole load.
+	_, err = b.LoadMany("a", "zz").Result()
+	assert.ErrorIs(t, err, futures.ErrMissingKey)
+}
@@ -0,0 +1,50 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Collector gathers futures whose number is not known upfront, in the manner
+// of a sync.WaitGroup: tasks spawning more work Add its futures as they go,
+// and Wait collects the results once all of them have settled. Add may be
+// called while another goroutine is in Wait. The zero value is ready to use.
+type Collector[T any] struct {
+	mu sync.Mutex
+	fs []*Future[T]
+}
+
+// Add starts f and adds it to the futures gathered by c.
+func (c *Collector[T]) Add(f *Future[T]) {
+	f.Start()
+	c.mu.Lock()
+	c.fs = append(c.fs, f)
+	c.mu.Unlock()
+}
+
+// Len returns the number of futures added so far.
+func (c *Collector[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.fs)
+}
+
+// Wait waits until every future added so far has settled, including those
+// added while it waits, and returns their values in the order they were
+// added. Errors are reported as by Results: an AggregateError holding the
+// failures, or, if ctx ends first, a *PendingError listing the futures still
+// running, with the values already gathered kept.
+func (c *Collector[T]) Wait(ctx context.Context) ([]T, error) {
+	for {
+		c.mu.Lock()
+		fs := c.fs[:len(c.fs):len(c.fs)]
+		c.mu.Unlock()
+
+		values, err := Results(ctx, fs...)
+		var pending *PendingError
+		if errors.As(err, &pending) || c.Len() == len(fs) {
+			return values, err
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorGathersDynamicFanOut(t *testing.T) {
+	var c futures.Collector[int]
+
+	// Each node adds its children while the collector is being waited on.
+	var visit func(n int) *futures.Future[int]
+	visit = func(n int) *futures.Future[int] {
+		return futures.NewFuture(func() (int, error) {
+			time.Sleep(time.Millisecond)
+			if n < 8 {
+				c.Add(visit(2 * n))
+				c.Add(visit(2*n + 1))
+			}
+			return n, nil
+		})
+	}
+	c.Add(visit(1))
+
+	values, err := c.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, values[0])
+	sort.Ints(values)
+	want := make([]int, 15)
+	for i := range want {
+		want[i] = i + 1
+	}
+	assert.Equal(t, want, values)
+	assert.Equal(t, 15, c.Len())
+}
+
+func TestCollectorReportsFailuresAndPending(t *testing.T) {
+	var c futures.Collector[string]
+	c.Add(futures.Resolved("a"))
+	c.Add(futures.Failed[string](errors.New("boom")))
+	_, err := c.Wait(context.Background())
+	var agg *futures.AggregateError
+	assert.ErrorAs(t, err, &agg)
+	assert.Equal(t, []int{1}, agg.Indices)
+
+	p := futures.NewPromise[string]()
+	c.Add(p.Future())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	values, err := c.Wait(ctx)
+	var pending *futures.PendingError
+	assert.ErrorAs(t, err, &pending)
+	assert.Equal(t, []int{2}, pending.Pending)
+	assert.Equal(t, "a", values[0])
+}
@@ -0,0 +1,334 @@
+package futures
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// All starts fs and returns a future resolving with their results in input
+// order. It rejects with the first error that occurs, without waiting for the
+// remaining inputs, wrapped in an AggregateError recording which input failed.
+func All[T any](fs ...*Future[T]) *Future[[]T] {
+	p := NewPromise[[]T]()
+	results := make([]T, len(fs))
+	if len(fs) == 0 {
+		p.Complete(results)
+		return p.Future()
+	}
+
+	var remaining atomic.Int64
+	remaining.Store(int64(len(fs)))
+	for i, f := range fs {
+		dependOn(p.future, f)
+		f.Start()
+		go func() {
+			v, err := f.await()
+			if err != nil {
+				p.Fail(&AggregateError{Errors: []error{err}, Indices: []int{i}})
+				return
+			}
+			results[i] = v
+			if remaining.Add(-1) == 0 {
+				p.Complete(results)
+			}
+		}()
+	}
+	return p.Future()
+}
+
+// AllJoined is like All but always waits for every input. If any failed, it
+// rejects with an AggregateError holding their errors in input order.
+func AllJoined[T any](fs ...*Future[T]) *Future[[]T] {
+	f := NewFuture(func() ([]T, error) {
+		results := make([]T, len(fs))
+		errs := make([]error, len(fs))
+		for i, f := range fs {
+			results[i], errs[i] = f.await()
+		}
+		if err := aggregate(errs); err != nil {
+			return nil, err
+		}
+		return results, nil
+	})
+
+	for _, in := range fs {
+		dependOn(f, in)
+		in.Start()
+	}
+	f.Start()
+	return f
+}
+
+// Race starts fs and returns a future settling like the first of them to
+// settle, whether it succeeded or failed. The other inputs are cancelled once
+// the winner is known.
+func Race[T any](fs ...*Future[T]) *Future[T] {
+	p := NewPromise[T]()
+	if len(fs) == 0 {
+		p.Fail(ErrNoFutures)
+		return p.Future()
+	}
+
+	for _, f := range fs {
+		dependOn(p.future, f)
+		f.Start()
+		go func() {
+			v, err := f.await()
+			if p.future.complete(v, err) {
+				cancelOthers(fs, f)
+			}
+		}()
+	}
+	return p.Future()
+}
+
+// FirstOf races fs like Race under ctx: it returns the outcome of the first
+// input to settle, or ctx.Err() if ctx ends first. Either way every other
+// input is cancelled, so a group of lookups can be raced under one deadline
+// in a single call:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+//	defer cancel()
+//	addr, err := futures.FirstOf(ctx, lookupPrimary(), lookupReplica())
+func FirstOf[T any](ctx context.Context, fs ...*Future[T]) (T, error) {
+	race := Race(fs...)
+	select {
+	case <-race.doneChan():
+		return race.outcome()
+	case <-ctx.Done():
+		for _, f := range fs {
+			f.Cancel()
+		}
+		race.Cancel()
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// AnyResult is the outcome of WhenAny: the first input to settle, where it
+// was in the input list, and every other input.
+type AnyResult[T any] struct {
+	Value     T
+	Err       error
+	Index     int
+	Remaining []*Future[T] // In input order; some may have settled since
+}
+
+// WhenAny starts fs and returns a future resolving as soon as one of them
+// settles, with its outcome and index. Unlike Race it does not cancel the
+// other inputs: they are handed back in Remaining so the caller can keep
+// consuming them, for instance by calling WhenAny again, or cancel them. The
+// returned future only rejects if fs is empty, or when cancelled, in which
+// case the inputs are cancelled too.
+func WhenAny[T any](fs ...*Future[T]) *Future[AnyResult[T]] {
+	p := NewPromise[AnyResult[T]]()
+	if len(fs) == 0 {
+		p.Fail(ErrNoFutures)
+		return p.Future()
+	}
+
+	var once sync.Once
+	for i, f := range fs {
+		dependOn(p.future, f)
+		f.Start()
+		go func() {
+			v, err := f.await()
+			once.Do(func() {
+				res := AnyResult[T]{Value: v, Err: err, Index: i}
+				for j, other := range fs {
+					if j != i {
+						res.Remaining = append(res.Remaining, other)
+					}
+				}
+				p.Complete(res)
+			})
+		}()
+	}
+	return p.Future()
+}
+
+// Any starts fs and returns a future resolving with the first successful
+// result; the other inputs are then cancelled. It rejects only if every input
+// fails, with an AggregateError holding their errors in input order.
+func Any[T any](fs ...*Future[T]) *Future[T] {
+	p := NewPromise[T]()
+	if len(fs) == 0 {
+		p.Fail(ErrNoFutures)
+		return p.Future()
+	}
+
+	errs := make([]error, len(fs))
+	var remaining atomic.Int64
+	remaining.Store(int64(len(fs)))
+	for i, f := range fs {
+		dependOn(p.future, f)
+		f.Start()
+		go func() {
+			v, err := f.await()
+			if err == nil {
+				if p.Complete(v) {
+					cancelOthers(fs, f)
+				}
+				return
+			}
+			errs[i] = err
+			if remaining.Add(-1) == 0 {
+				p.Fail(aggregate(errs))
+			}
+		}()
+	}
+	return p.Future()
+}
+
+// FirstN starts fs and returns a future resolving with the first n successful
+// results, in the order they arrived; the other inputs are then cancelled.
+// It rejects with ErrQuorumUnreachable, wrapping an AggregateError of the
+// failures so far, as soon as too many inputs have failed for n of them to
+// succeed. This suits quorum reads, where any n of the replicas will do.
+func FirstN[T any](n int, fs ...*Future[T]) *Future[[]T] {
+	p := NewPromise[[]T]()
+	if n <= 0 {
+		p.Complete([]T{})
+		return p.Future()
+	}
+	if n > len(fs) {
+		p.Fail(fmt.Errorf("%w: need %d of %d", ErrQuorumUnreachable, n, len(fs)))
+		return p.Future()
+	}
+
+	var mu sync.Mutex
+	var results []T
+	var errs []error
+	for _, f := range fs {
+		dependOn(p.future, f)
+		f.Start()
+		go func() {
+			v, err := f.await()
+			mu.Lock()
+			var won []T
+			var lost error
+			if err != nil {
+				errs = append(errs, err)
+				if len(fs)-len(errs) < n {
+					lost = fmt.Errorf("%w: %w", ErrQuorumUnreachable, aggregate(errs))
+				}
+			} else if results = append(results, v); len(results) == n {
+				won = slices.Clone(results)
+			}
+			mu.Unlock()
+
+			switch {
+			case lost != nil:
+				p.Fail(lost)
+			case won != nil:
+				p.Complete(won)
+				cancelOthers(fs, nil)
+			}
+		}()
+	}
+	return p.Future()
+}
+
+func cancelOthers[T any](fs []*Future[T], winner *Future[T]) {
+	for _, f := range fs {
+		if f != winner {
+			f.Cancel()
+		}
+	}
+}
+
+// AllSettled starts fs and returns a future resolving, once every input has
+// settled, with each outcome in input order. It never rejects, so partial
+// success can be handled by the caller; AggregateResults turns the failures
+// into an AggregateError.
+func AllSettled[T any](fs ...*Future[T]) *Future[[]Result[T]] {
+	f := NewFuture(func() ([]Result[T], error) {
+		out := make([]Result[T], len(fs))
+		for i, f := range fs {
+			out[i].Value, out[i].Err = f.await()
+		}
+		return out, nil
+	})
+
+	for _, in := range fs {
+		dependOn(f, in)
+		in.Start()
+	}
+	f.Start()
+	return f
+}
+
+// Reduce starts fs and folds their results into init with fn, in input
+// order: each result is folded in once it and all earlier ones are known. It
+// fails fast, rejecting with the first failure to occur, wrapped in an
+// AggregateError recording which input failed, without waiting for the
+// remaining inputs. fn is never called concurrently; if it panics, the
+// result rejects with a *PanicError.
+func Reduce[T, A any](fs []*Future[T], init A, fn func(A, T) A) *Future[A] {
+	return reduce(fs, init, fn, true)
+}
+
+// ReduceUnordered is Reduce folding results in the order the futures settle,
+// so that fast inputs are not held up by slow ones. It suits operations such
+// as sums and merges whose outcome does not depend on the order.
+func ReduceUnordered[T, A any](fs []*Future[T], init A, fn func(A, T) A) *Future[A] {
+	return reduce(fs, init, fn, false)
+}
+
+func reduce[T, A any](fs []*Future[T], init A, fn func(A, T) A, ordered bool) *Future[A] {
+	p := NewPromise[A]()
+	if len(fs) == 0 {
+		p.Complete(init)
+		return p.Future()
+	}
+
+	settled := make(chan int, len(fs))
+	for i, f := range fs {
+		dependOn(p.future, f)
+		f.Start()
+		go func() {
+			<-f.doneChan()
+			settled <- i
+		}()
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				p.Fail(&PanicError{Value: r, Stack: debug.Stack()})
+			}
+		}()
+		acc := init
+		ready := make([]bool, len(fs))
+		folded := 0
+		for range fs {
+			var i int
+			select {
+			case i = <-settled:
+			case <-p.future.settled():
+				return
+			}
+			v, err, _ := fs[i].TryResult()
+			if err != nil {
+				p.Fail(&AggregateError{Errors: []error{err}, Indices: []int{i}})
+				return
+			}
+			if !ordered {
+				acc = fn(acc, v)
+				continue
+			}
+			ready[i] = true
+			for folded < len(fs) && ready[folded] {
+				v, _, _ := fs[folded].TryResult()
+				acc = fn(acc, v)
+				folded++
+			}
+		}
+		p.Complete(acc)
+	}()
+	return p.Future()
+}
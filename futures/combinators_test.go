@@ -0,0 +1,209 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func after[T any](d time.Duration, v T, err error) *futures.Future[T] {
+	return futures.NewFuture(func() (T, error) {
+		time.Sleep(d)
+		return v, err
+	})
+}
+
+func TestAllKeepsInputOrder(t *testing.T) {
+	results, err := futures.All(
+		after(30*time.Millisecond, 1, nil),
+		after(0, 2, nil),
+		after(10*time.Millisecond, 3, nil),
+	).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, results)
+
+	empty, err := futures.All[int]().Result()
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestAllFailsFast(t *testing.T) {
+	boom := errors.New("boom")
+	start := time.Now()
+	_, err := futures.All(
+		after(time.Second, 1, nil),
+		after(0, 0, boom),
+	).Result()
+	assert.ErrorIs(t, err, boom)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestAllJoinedAggregatesErrors(t *testing.T) {
+	first, second := errors.New("first"), errors.New("second")
+	_, err := futures.AllJoined(
+		after(0, 0, first),
+		after(0, 1, nil),
+		after(10*time.Millisecond, 0, second),
+	).Result()
+	assert.ErrorIs(t, err, first)
+	assert.ErrorIs(t, err, second)
+}
+
+func TestRaceSettlesWithFirstAndCancelsLosers(t *testing.T) {
+	slow := after(time.Second, "slow", nil)
+	boom := errors.New("boom")
+
+	_, err := futures.Race(slow, after(0, "", boom)).Result()
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, futures.Cancelled, slow.State())
+}
+
+func TestWhenAnyHandsBackRemainingFutures(t *testing.T) {
+	boom := errors.New("boom")
+	first, err := futures.WhenAny(
+		after(50*time.Millisecond, "b", nil),
+		after(0, "", boom),
+		after(100*time.Millisecond, "c", nil),
+	).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first.Index)
+	assert.ErrorIs(t, first.Err, boom)
+	assert.Len(t, first.Remaining, 2)
+
+	next, err := futures.WhenAny(first.Remaining...).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "b", next.Value)
+	assert.Equal(t, 0, next.Index)
+	next.Remaining[0].Cancel()
+	assert.True(t, next.Remaining[0].IsCancelled())
+
+	_, err = futures.WhenAny[int]().Result()
+	assert.ErrorIs(t, err, futures.ErrNoFutures)
+}
+
+func TestAnyIgnoresFailuresUntilAllFail(t *testing.T) {
+	slow := after(time.Second, "slow", nil)
+	v, err := futures.Any(
+		after(0, "", errors.New("down")),
+		after(10*time.Millisecond, "replica", nil),
+		slow,
+	).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "replica", v)
+	assert.Equal(t, futures.Cancelled, slow.State())
+
+	a, b := errors.New("a"), errors.New("b")
+	_, err = futures.Any(after(0, 0, a), after(0, 0, b)).Result()
+	assert.ErrorIs(t, err, a)
+	assert.ErrorIs(t, err, b)
+
+	_, err = futures.Any[int]().Result()
+	assert.ErrorIs(t, err, futures.ErrNoFutures)
+}
+
+func TestAllSettledNeverShortCircuits(t *testing.T) {
+	boom := errors.New("boom")
+	out, err := futures.AllSettled(
+		after(0, 1, nil),
+		after(0, 0, boom),
+		after(20*time.Millisecond, 3, nil),
+	).Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []futures.Settled[int]{{Value: 1}, {Err: boom}, {Value: 3}}, out)
+}
+
+func TestFirstNResolvesWithQuorum(t *testing.T) {
+	slow := after(time.Hour, 9, nil)
+	results, err := futures.FirstN(2,
+		after(20*time.Millisecond, 1, nil),
+		after(0, 0, errors.New("replica down")),
+		after(0, 2, nil),
+		slow,
+	).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, results)
+	assert.Equal(t, futures.Cancelled, slow.State())
+}
+
+func TestFirstNRejectsOnceQuorumIsUnreachable(t *testing.T) {
+	down := errors.New("down")
+	_, err := futures.FirstN(2,
+		after(0, 0, down),
+		after(time.Hour, 1, nil),
+		after(0, 0, down),
+	).Result()
+	assert.ErrorIs(t, err, futures.ErrQuorumUnreachable)
+	assert.ErrorIs(t, err, down)
+
+	_, err = futures.FirstN(3, futures.Resolved(1)).Result()
+	assert.ErrorIs(t, err, futures.ErrQuorumUnreachable)
+}
+
+func TestReduceFoldsInInputOrder(t *testing.T) {
+	concat := func(acc string, s string) string { return acc + s }
+	inputs := func() []*futures.Future[string] {
+		return []*futures.Future[string]{
+			after(30*time.Millisecond, "a", nil),
+			after(0, "b", nil),
+			after(10*time.Millisecond, "c", nil),
+		}
+	}
+
+	v, err := futures.Reduce(inputs(), ">", concat).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, ">abc", v)
+
+	v, err = futures.ReduceUnordered(inputs(), ">", concat).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, ">bca", v)
+
+	v, err = futures.Reduce(nil, ">", concat).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, ">", v)
+}
+
+func TestReduceFailsFast(t *testing.T) {
+	boom := errors.New("boom")
+	slow := after(time.Second, 1, nil)
+	start := time.Now()
+	_, err := futures.Reduce([]*futures.Future[int]{slow, after(0, 0, boom)}, 0, func(a, b int) int { return a + b }).Result()
+	assert.ErrorIs(t, err, boom)
+	var agg *futures.AggregateError
+	assert.ErrorAs(t, err, &agg)
+	assert.Equal(t, []int{1}, agg.Indices)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestReduceRecoversPanickingFold(t *testing.T) {
+	fs := []*futures.Future[int]{futures.Resolved(1), futures.Resolved(2)}
+	for _, reduce := range []func([]*futures.Future[int], int, func(int, int) int) *futures.Future[int]{
+		futures.Reduce[int, int], futures.ReduceUnordered[int, int],
+	} {
+		_, err := reduce(fs, 0, func(a, b int) int { panic("boom") }).Result()
+		var pe *futures.PanicError
+		if assert.ErrorAs(t, err, &pe) {
+			assert.Equal(t, "boom", pe.Value)
+		}
+	}
+}
+
+func TestFirstOfReturnsWinnerOrContextError(t *testing.T) {
+	slow := after(time.Second, "slow", nil)
+	v, err := futures.FirstOf(context.Background(), slow, after(0, "fast", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "fast", v)
+	assert.Eventually(t, slow.IsCancelled, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	a, b := after(time.Second, 1, nil), after(time.Second, 2, nil)
+	_, err = futures.FirstOf(ctx, a, b)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, a.IsCancelled())
+	assert.True(t, b.IsCancelled())
+}
@@ -0,0 +1,70 @@
+package futures
+
+import "sync"
+
+// completionQueue holds a future's user callbacks so that they run one at a
+// time, in the order they were queued. Callbacks registered before the future
+// settles are queued as it settles and run by the settling goroutine; those
+// registered later join the same queue, so they never overtake earlier ones,
+// and are run asynchronously by whoever finds the queue idle.
+type completionQueue struct {
+	mu      sync.Mutex
+	pending []func()
+	running bool
+}
+
+// push queues fn and reports whether the queue was idle, in which case the
+// caller must arrange for the queue to be drained.
+func (q *completionQueue) push(fn func()) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, fn)
+	if q.running {
+		return false
+	}
+	q.running = true
+	return true
+}
+
+// pop takes the next callback, or marks the queue idle and reports false if
+// there is none.
+func (q *completionQueue) pop() (func(), bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) == 0 {
+		q.pending, q.running = nil, false
+		return nil, false
+	}
+	fn := q.pending[0]
+	q.pending[0] = nil
+	q.pending = q.pending[1:]
+	return fn, true
+}
+
+// busy reports whether callbacks are queued or running.
+func (q *completionQueue) busy() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.running
+}
+
+// runCallbacks drains the future's callback queue, including callbacks
+// queued while it runs.
+func (f *Future[T]) runCallbacks() {
+	for fn, ok := f.queue.pop(); ok; fn, ok = f.queue.pop() {
+		f.callback(fn)
+	}
+}
+
+// schedule queues a callback registered once the future has settled. It runs
+// after the callbacks queued before it, on the future's executor if it has
+// one (inline for a direct executor) and on a goroutine of its own otherwise.
+// It must not be called with f.mutex held.
+func (f *Future[T]) schedule(fn func()) {
+	if !f.queue.push(fn) {
+		return
+	}
+	if f.executor == nil || f.executor.submit(execTask{run: f.runCallbacks}, false) != nil {
+		go f.runCallbacks()
+	}
+}
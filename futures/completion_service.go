@@ -0,0 +1,120 @@
+package futures
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// CompletionService runs submitted tasks on an executor and hands their
+// futures to a consumer in the order they finish, in the spirit of Java's
+// ExecutorCompletionService. Tasks may be submitted while results are being
+// taken.
+type CompletionService[T any] struct {
+	executor *Executor
+
+	mu      sync.Mutex
+	ready   []*Future[T]
+	pending int // submitted futures not taken yet
+	wake    chan struct{}
+}
+
+// NewCompletionService creates a completion service running tasks on e, or on
+// a goroutine each if e is nil.
+func NewCompletionService[T any](e *Executor) *CompletionService[T] {
+	return &CompletionService[T]{executor: e, wake: make(chan struct{}, 1)}
+}
+
+// Submit starts task and returns its future. The future is also delivered to
+// Take or Poll once it settles.
+func (cs *CompletionService[T]) Submit(task func() (T, error)) *Future[T] {
+	f := newFuture(task, cs.executor, options{})
+	cs.mu.Lock()
+	cs.pending++
+	cs.mu.Unlock()
+	f.Start()
+
+	go func() {
+		<-f.doneChan()
+		cs.mu.Lock()
+		cs.ready = append(cs.ready, f)
+		cs.mu.Unlock()
+		cs.signal()
+	}()
+	return f
+}
+
+// Take waits for the next settled future, or returns ctx.Err() if ctx ends
+// first.
+func (cs *CompletionService[T]) Take(ctx context.Context) (*Future[T], error) {
+	for {
+		if f, ok := cs.Poll(); ok {
+			return f, nil
+		}
+
+		select {
+		case <-cs.wake:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Poll returns the next settled future without waiting, if there is one.
+func (cs *CompletionService[T]) Poll() (*Future[T], bool) {
+	cs.mu.Lock()
+	if len(cs.ready) == 0 {
+		cs.mu.Unlock()
+		return nil, false
+	}
+	f := cs.ready[0]
+	cs.ready = cs.ready[1:]
+	cs.pending--
+	more := len(cs.ready) > 0
+	cs.mu.Unlock()
+
+	// Pass the wake-up on so another consumer sees the remaining futures.
+	if more {
+		cs.signal()
+	}
+	return f, true
+}
+
+// Results yields the outcome of each submitted task in the order the tasks
+// finish. It stops once every task submitted so far has been taken, or yields
+// ctx.Err() as a final error if ctx ends first. Tasks submitted during the
+// iteration are included.
+func (cs *CompletionService[T]) Results(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			if f, ok := cs.Poll(); ok {
+				if !yield(f.outcome()) {
+					return
+				}
+				continue
+			}
+
+			cs.mu.Lock()
+			idle := cs.pending == 0
+			cs.mu.Unlock()
+			if idle {
+				return
+			}
+
+			select {
+			case <-cs.wake:
+			case <-ctx.Done():
+				var zero T
+				yield(zero, ctx.Err())
+				return
+			}
+		}
+	}
+}
+
+func (cs *CompletionService[T]) signal() {
+	select {
+	case cs.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,55 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletionServiceDeliversInCompletionOrder(t *testing.T) {
+	cs := futures.NewCompletionService[int](futures.NewExecutor(4, 4))
+	for _, d := range []int{40, 0, 20} {
+		cs.Submit(func() (int, error) {
+			time.Sleep(time.Duration(d) * time.Millisecond)
+			return d, nil
+		})
+	}
+
+	ctx := context.Background()
+	var got []int
+	for range 3 {
+		f, err := cs.Take(ctx)
+		assert.NoError(t, err)
+		v, _ := f.Result()
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{0, 20, 40}, got)
+
+	_, ok := cs.Poll()
+	assert.False(t, ok)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := cs.Take(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCompletionServiceResultsIterator(t *testing.T) {
+	cs := futures.NewCompletionService[int](nil)
+	for _, d := range []int{30, 10} {
+		cs.Submit(func() (int, error) {
+			time.Sleep(time.Duration(d) * time.Millisecond)
+			return d, nil
+		})
+	}
+
+	var got []int
+	for v, err := range cs.Results(context.Background()) {
+		assert.NoError(t, err)
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{10, 30}, got)
+}
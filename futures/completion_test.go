@@ -0,0 +1,98 @@
+package futures_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+// Run with -race: these hammer the transition to a terminal state from
+// every direction at once.
+
+func TestCompletionSettlesOnceUnderRacingCompleters(t *testing.T) {
+	boom := errors.New("boom")
+	for range 500 {
+		p := futures.NewPromise[int]()
+		f := p.Future()
+		var callbacks, completions atomic.Int32
+		f.OnComplete(func(int, error) { callbacks.Add(1) })
+		f.OnSuccess(func(int) { callbacks.Add(1) })
+		f.OnFailure(func(error) { callbacks.Add(1) })
+
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		settlers := []func() bool{
+			func() bool { return p.Complete(1) },
+			func() bool { return p.Fail(boom) },
+			f.Cancel,
+			func() bool { return p.Resolve(2, nil) },
+		}
+		for _, settle := range settlers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				if settle() {
+					completions.Add(1)
+				}
+			}()
+		}
+		results := make(chan error, 4)
+		for range 4 {
+			go func() {
+				_, err := f.Result()
+				results <- err
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), completions.Load())
+		v, err, ok := f.TryResult()
+		assert.True(t, ok)
+		for range 4 {
+			assert.Equal(t, err, <-results)
+		}
+		switch f.State() {
+		case futures.Fulfilled:
+			assert.Contains(t, []int{1, 2}, v)
+		case futures.Rejected:
+			assert.ErrorIs(t, err, boom)
+		case futures.Cancelled:
+			assert.ErrorIs(t, err, futures.ErrCancelled)
+		}
+		<-f.Done()
+		// OnComplete plus exactly one of OnSuccess and OnFailure.
+		assert.Equal(t, int32(2), callbacks.Load())
+	}
+}
+
+func TestCompletionSettlesOnceWhenTaskTimeoutAndCancelRace(t *testing.T) {
+	for range 200 {
+		var settled atomic.Int32
+		f := futures.NewFuture(func() (int, error) {
+			time.Sleep(time.Millisecond)
+			return 1, nil
+		}).WithTimeout(time.Millisecond)
+		f.OnComplete(func(int, error) { settled.Add(1) })
+		f.Start()
+		go f.Cancel()
+		d := futures.Then(f, func(n int) (int, error) { return n, nil })
+
+		_, err := f.Result()
+		_, derr := d.Result()
+		<-f.Done()
+		if err == nil {
+			assert.NoError(t, derr)
+		} else {
+			assert.Error(t, derr)
+		}
+		assert.False(t, f.Cancel())
+		assert.Equal(t, int32(1), settled.Load())
+	}
+}
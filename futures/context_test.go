@@ -0,0 +1,140 @@
+package futures_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFutureCtxRejectsOnTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	taskStopped := make(chan struct{})
+	f := futures.NewFutureCtx(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		close(taskStopped)
+		return 0, ctx.Err()
+	})
+
+	_, err := f.Result()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, futures.Rejected, f.State())
+	<-taskStopped
+}
+
+func TestCancelStopsContextTask(t *testing.T) {
+	taskStopped := make(chan struct{})
+	f := futures.NewFutureCtx(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		close(taskStopped)
+		return 0, ctx.Err()
+	})
+	f.Start()
+
+	assert.True(t, f.Cancel())
+	<-taskStopped
+	_, err := f.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
+
+func TestNewFutureCtxPassesValues(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "request-42")
+
+	id, err := futures.NewFutureCtx(ctx, func(ctx context.Context) (string, error) {
+		return ctx.Value(key{}).(string), nil
+	}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "request-42", id)
+}
+
+func TestNewFutureCtxCancelledByContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := futures.NewFutureCtx(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	cleaned := make(chan struct{})
+	f.OnCancel(func() { close(cleaned) })
+	f.Start()
+
+	cancel()
+	<-cleaned
+	_, err := f.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, futures.Cancelled, f.State())
+}
+
+func TestNewFutureCtxTaskReturningOnCancellationIsCancelled(t *testing.T) {
+	// The task sees its context end and returns before the context's own
+	// cancellation hook settles the future; it must still count as cancelled.
+	for range 50 {
+		ctx, cancel := context.WithCancel(context.Background())
+		var onCancel atomic.Bool
+		f := futures.NewFutureCtx(ctx, func(ctx context.Context) (int, error) {
+			cancel()
+			return 0, ctx.Err()
+		})
+		f.OnCancel(func() { onCancel.Store(true) })
+
+		_, err := f.Result()
+		assert.ErrorIs(t, err, futures.ErrCancelled)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, futures.Cancelled, f.State())
+		assert.True(t, onCancel.Load())
+	}
+}
+
+type ctxKey struct{}
+
+func TestThenCtxCarriesTheRootContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "req-7"))
+	defer cancel()
+	root := futures.NewFutureCtx(ctx, func(context.Context) (int, error) { return 1, nil })
+
+	seen := make(chan any, 1)
+	stage := futures.ThenCtx(root, func(ctx context.Context, v int) (int, error) {
+		seen <- ctx.Value(ctxKey{})
+		<-ctx.Done()
+		return v, ctx.Err()
+	})
+	assert.Equal(t, "req-7", <-seen)
+	assert.Equal(t, futures.Running, stage.State())
+
+	// The root has settled; ending its context still aborts the stage.
+	cancel()
+	_, err := stage.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, futures.Cancelled, stage.State())
+}
+
+func TestThenCtxHonoursDeadlinesAndCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	root := futures.NewFutureCtx(ctx, func(context.Context) (int, error) { return 1, nil })
+	slow := futures.ThenCtx(root, func(ctx context.Context, v int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	_, err := slow.Result()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, futures.Rejected, slow.State())
+
+	// Without a root context, the step's context ends when it is cancelled.
+	aborted := make(chan struct{})
+	stage := futures.ThenCtx(futures.Resolved(1), func(ctx context.Context, v int) (int, error) {
+		<-ctx.Done()
+		close(aborted)
+		return 0, ctx.Err()
+	})
+	assert.Eventually(t, func() bool { return stage.State() == futures.Running }, time.Second, time.Millisecond)
+	stage.Cancel()
+	<-aborted
+}
@@ -0,0 +1,157 @@
+package futures
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule decides when a Scheduler runs its task.
+type Schedule interface {
+	// Next returns the first run time strictly after t, or the zero time if
+	// there is none.
+	Next(t time.Time) time.Time
+}
+
+// Every returns a Schedule running every d, counted from the previous run.
+func Every(d time.Duration) Schedule {
+	return every(d)
+}
+
+type every time.Duration
+
+func (d every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(d))
+}
+
+// cronSchedule holds, for each field, the set of allowed values as a bitmask.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	anyDom, anyDow                bool
+}
+
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// Cron parses a standard five-field cron expression, "minute hour
+// day-of-month month day-of-week", into a Schedule in the local time zone of
+// the times it is given. Each field is "*" or a comma-separated list of
+// values and ranges ("1-5"), optionally with a step ("*/15", "0-30/10").
+// Sunday is day 0. As in cron, when both day fields are restricted a day
+// matching either one qualifies.
+func Cron(spec string) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("futures: cron spec %q: want 5 fields, got %d", spec, len(fields))
+	}
+	var masks [5]uint64
+	for i, field := range fields {
+		m, err := parseCronField(field, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("futures: cron spec %q: %s: %w", spec, cronFields[i].name, err)
+		}
+		masks[i] = m
+	}
+	return &cronSchedule{
+		minute: masks[0],
+		hour:   masks[1],
+		dom:    masks[2],
+		month:  masks[3],
+		dow:    masks[4],
+		anyDom: fields[2] == "*",
+		anyDow: fields[4] == "*",
+	}, nil
+}
+
+// MustCron is like Cron but panics if spec is invalid.
+func MustCron(spec string) Schedule {
+	s, err := Cron(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("bad step %q", stepStr)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loStr); err != nil {
+				return 0, fmt.Errorf("bad value %q", loStr)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return 0, fmt.Errorf("bad value %q", hiStr)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << v
+		}
+	}
+	return mask, nil
+}
+
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// Every combination recurs within a few years; give up after that.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	default:
+		return dom || dow
+	}
+}
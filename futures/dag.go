@@ -0,0 +1,258 @@
+package futures
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// InputKey is the key under which every step receives the input passed to
+// DAG.Run.
+const InputKey = "$input"
+
+// StepFunc computes a step from its inputs: the outputs of the steps it
+// depends on keyed by step name, plus the run input under InputKey.
+type StepFunc func(inputs map[string]any) (any, error)
+
+// Step declares one node of a DAG. Everything but Run is part of the
+// definition exported by DAG.MarshalJSON.
+type Step struct {
+	Name      string            `json:"name"`
+	DependsOn []string          `json:"depends_on,omitempty"`
+	Retries   int               `json:"retries,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Run       StepFunc          `json:"-"`
+}
+
+// DAG is a named set of steps with dependencies between them. Independent
+// steps run concurrently; a step starts once all of its dependencies have
+// succeeded. Its structure can be serialized for audit, and every run
+// produces a RunRecord that can be replayed later.
+type DAG struct {
+	name  string
+	steps []Step
+	index map[string]int
+	exec  *Executor
+}
+
+// NewDAG creates an empty DAG.
+func NewDAG(name string) *DAG {
+	return &DAG{name: name, index: make(map[string]int)}
+}
+
+// WithExecutor runs the steps of later runs on e instead of on goroutines of
+// their own, and returns d. A ready step then waits for a worker, which shows
+// as queue wait in its record and in Report.
+func (d *DAG) WithExecutor(e *Executor) *DAG {
+	d.exec = e
+	return d
+}
+
+// Add appends a step. Dependencies must already have been added, which keeps
+// the graph acyclic and the steps in a valid execution order.
+func (d *DAG) Add(step Step) error {
+	if step.Run == nil {
+		return fmt.Errorf("futures: dag %q: step %q has no Run function", d.name, step.Name)
+	}
+	if _, exists := d.index[step.Name]; exists {
+		return fmt.Errorf("futures: dag %q: duplicate step %q", d.name, step.Name)
+	}
+	for _, dep := range step.DependsOn {
+		if _, ok := d.index[dep]; !ok {
+			return fmt.Errorf("futures: dag %q: step %q depends on unknown step %q", d.name, step.Name, dep)
+		}
+	}
+
+	d.index[step.Name] = len(d.steps)
+	d.steps = append(d.steps, step)
+	return nil
+}
+
+// MarshalJSON exports the structure of the DAG: step names, dependencies and
+// options, but not the step functions.
+func (d *DAG) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name  string `json:"name"`
+		Steps []Step `json:"steps"`
+	}{d.name, d.steps})
+}
+
+// RunRecord captures the inputs, outputs and timing of every step of a run.
+type RunRecord struct {
+	DAG   string       `json:"dag"`
+	Input any          `json:"input,omitempty"`
+	Steps []StepRecord `json:"steps"`
+}
+
+// StepRecord is the part of a RunRecord describing one step. Steps that never
+// ran because a dependency failed carry only the error.
+//
+// Ready is when the step's dependencies were satisfied and Started when it
+// actually began running; the difference is the time it spent waiting.
+type StepRecord struct {
+	Name      string         `json:"name"`
+	DependsOn []string       `json:"depends_on,omitempty"`
+	Inputs    map[string]any `json:"inputs,omitempty"`
+	Output    any            `json:"output,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	Attempts  int            `json:"attempts,omitempty"`
+	Ready     time.Time      `json:"ready"`
+	Started   time.Time      `json:"started"`
+	Finished  time.Time      `json:"finished"`
+}
+
+// DAGRun is a single execution of a DAG.
+type DAGRun struct {
+	future *Future[map[string]any]
+
+	mu     sync.Mutex
+	record RunRecord
+}
+
+// Future returns a future resolving with the output of every step keyed by
+// name, or rejecting with the first step failure.
+func (r *DAGRun) Future() *Future[map[string]any] {
+	return r.future
+}
+
+// Result waits for the run to finish.
+func (r *DAGRun) Result() (map[string]any, error) {
+	return r.future.Result()
+}
+
+// Record returns a snapshot of the run record. Once the run has finished the
+// record is complete.
+func (r *DAGRun) Record() RunRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec := r.record
+	rec.Steps = append([]StepRecord(nil), r.record.Steps...)
+	return rec
+}
+
+// Run executes the DAG with the given input.
+func (d *DAG) Run(input any) *DAGRun {
+	return d.execute(input, nil)
+}
+
+// Replay re-drives every step against the inputs recorded in rec instead of
+// the outputs of a fresh run, so that a past execution can be checked step by
+// step. Inputs decoded from JSON have JSON types (float64, map[string]any, ...).
+func (d *DAG) Replay(rec RunRecord) *DAGRun {
+	recorded := make(map[string]map[string]any, len(rec.Steps))
+	for _, s := range rec.Steps {
+		recorded[s.Name] = s.Inputs
+	}
+	return d.execute(rec.Input, recorded)
+}
+
+func (d *DAG) execute(input any, recorded map[string]map[string]any) *DAGRun {
+	run := &DAGRun{record: RunRecord{DAG: d.name, Input: input}}
+	run.record.Steps = make([]StepRecord, len(d.steps))
+
+	stepFutures := make([]*Future[any], len(d.steps))
+	for i, step := range d.steps {
+		run.record.Steps[i].Name = step.Name
+		run.record.Steps[i].DependsOn = step.DependsOn
+
+		stepFutures[i] = NewFuture(func() (any, error) {
+			if recorded != nil {
+				inputs, ok := recorded[step.Name]
+				if !ok {
+					err := fmt.Errorf("no recorded inputs for step %q", step.Name)
+					run.skip(i, err)
+					return nil, err
+				}
+				return d.schedule(run, i, step, inputs, time.Now())
+			}
+
+			inputs := map[string]any{InputKey: input}
+			ready := time.Now()
+			for j, dep := range step.DependsOn {
+				f := stepFutures[d.index[dep]]
+				v, err := f.Result()
+				if err != nil {
+					err = fmt.Errorf("dependency %q: %w", dep, err)
+					run.skip(i, err)
+					return nil, err
+				}
+				inputs[dep] = v
+				// The step is ready once its last dependency has settled.
+				if at := f.SettledAt(); j == 0 || at.After(ready) {
+					ready = at
+				}
+			}
+			return d.schedule(run, i, step, inputs, ready)
+		})
+	}
+
+	run.future = NewFuture(func() (map[string]any, error) {
+		outputs := make(map[string]any, len(stepFutures))
+		var firstErr error
+		for i, f := range stepFutures {
+			v, err := f.Result()
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("step %q: %w", d.steps[i].Name, err)
+			}
+			outputs[d.steps[i].Name] = v
+		}
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return outputs, nil
+	})
+
+	for _, f := range stepFutures {
+		f.Start()
+	}
+	run.future.Start()
+	return run
+}
+
+// schedule runs a step whose inputs are ready, on the DAG's executor if it has
+// one.
+func (d *DAG) schedule(run *DAGRun, i int, step Step, inputs map[string]any, ready time.Time) (any, error) {
+	if d.exec == nil {
+		return run.runStep(i, step, inputs, ready)
+	}
+	return Submit(d.exec, func() (any, error) {
+		return run.runStep(i, step, inputs, ready)
+	}).Result()
+}
+
+func (r *DAGRun) runStep(i int, step Step, inputs map[string]any, ready time.Time) (any, error) {
+	started := time.Now()
+
+	var out any
+	var err error
+	attempts := 0
+	for attempts <= step.Retries {
+		attempts++
+		if out, err = step.Run(inputs); err == nil {
+			break
+		}
+	}
+
+	r.mu.Lock()
+	s := &r.record.Steps[i]
+	s.Inputs = inputs
+	s.Output = out
+	s.Attempts = attempts
+	s.Ready = ready
+	s.Started = started
+	s.Finished = time.Now()
+	if err != nil {
+		s.Error = err.Error()
+	}
+	r.mu.Unlock()
+
+	return out, err
+}
+
+func (r *DAGRun) skip(i int, err error) {
+	r.mu.Lock()
+	r.record.Steps[i].Error = err.Error()
+	r.mu.Unlock()
+}
@@ -0,0 +1,157 @@
+package futures_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPriceDAG(t *testing.T) *futures.DAG {
+	dag := futures.NewDAG("pricing")
+	require.NoError(t, dag.Add(futures.Step{
+		Name: "base",
+		Run: func(in map[string]any) (any, error) {
+			return in[futures.InputKey].(float64) * 2, nil
+		},
+	}))
+	require.NoError(t, dag.Add(futures.Step{
+		Name: "tax",
+		Run: func(in map[string]any) (any, error) {
+			return in[futures.InputKey].(float64) / 10, nil
+		},
+	}))
+	require.NoError(t, dag.Add(futures.Step{
+		Name:      "total",
+		DependsOn: []string{"base", "tax"},
+		Retries:   1,
+		Labels:    map[string]string{"owner": "billing"},
+		Run: func(in map[string]any) (any, error) {
+			return in["base"].(float64) + in["tax"].(float64), nil
+		},
+	}))
+	return dag
+}
+
+func TestDAGRunAndExportDefinition(t *testing.T) {
+	dag := newPriceDAG(t)
+
+	out, err := dag.Run(10.0).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 21.0, out["total"])
+
+	def, err := json.Marshal(dag)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"pricing","steps":[
+		{"name":"base"},
+		{"name":"tax"},
+		{"name":"total","depends_on":["base","tax"],"retries":1,"labels":{"owner":"billing"}}
+	]}`, string(def))
+
+	assert.Error(t, dag.Add(futures.Step{Name: "x", DependsOn: []string{"nope"}, Run: func(map[string]any) (any, error) { return nil, nil }}))
+}
+
+func TestDAGReplayFromRecordedInputs(t *testing.T) {
+	dag := newPriceDAG(t)
+	run := dag.Run(10.0)
+	_, err := run.Result()
+	assert.NoError(t, err)
+
+	// Round-trip the record the way an audit log would store it.
+	raw, err := json.Marshal(run.Record())
+	assert.NoError(t, err)
+	var rec futures.RunRecord
+	assert.NoError(t, json.Unmarshal(raw, &rec))
+
+	out, err := dag.Replay(rec).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 21.0, out["total"])
+}
+
+func TestDAGFailureSkipsDependents(t *testing.T) {
+	dag := futures.NewDAG("broken")
+	boom := errors.New("boom")
+	assert.NoError(t, dag.Add(futures.Step{Name: "a", Run: func(map[string]any) (any, error) { return nil, boom }}))
+	assert.NoError(t, dag.Add(futures.Step{Name: "b", DependsOn: []string{"a"}, Run: func(map[string]any) (any, error) {
+		t.Error("b must not run")
+		return nil, nil
+	}}))
+
+	run := dag.Run(nil)
+	_, err := run.Result()
+	assert.ErrorIs(t, err, boom)
+
+	rec := run.Record()
+	assert.Equal(t, "boom", rec.Steps[0].Error)
+	assert.Contains(t, rec.Steps[1].Error, `dependency "a"`)
+	assert.Zero(t, rec.Steps[1].Attempts)
+}
+
+func TestRunRecordReportFindsCriticalPath(t *testing.T) {
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return origin.Add(time.Duration(ms) * time.Millisecond) }
+
+	rec := futures.RunRecord{DAG: "etl", Steps: []futures.StepRecord{
+		{Name: "fetch", Ready: at(0), Started: at(0), Finished: at(50)},
+		{Name: "config", Ready: at(0), Started: at(0), Finished: at(5)},
+		{Name: "parse", DependsOn: []string{"fetch", "config"}, Ready: at(50), Started: at(60), Finished: at(80)},
+		{Name: "store", DependsOn: []string{"parse"}, Ready: at(80), Started: at(80), Finished: at(90)},
+	}}
+
+	report := rec.Report()
+	assert.Equal(t, 90*time.Millisecond, report.Total)
+
+	var path []string
+	for _, s := range report.CriticalPath {
+		path = append(path, s.Name)
+	}
+	assert.Equal(t, []string{"fetch", "parse", "store"}, path)
+
+	slowest := report.Slowest(1)
+	assert.Equal(t, "fetch", slowest[0].Name)
+	assert.Equal(t, 10*time.Millisecond, report.CriticalPath[1].QueueWait)
+	assert.Contains(t, report.String(), "critical path: fetch -> parse -> store")
+}
+
+func TestDAGRecordsQueueWaitOnSaturatedExecutor(t *testing.T) {
+	e := futures.NewExecutor(1, 8)
+	defer e.Shutdown(context.Background())
+
+	dag := futures.NewDAG("busy").WithExecutor(e)
+	slow := func(map[string]any) (any, error) {
+		time.Sleep(20 * time.Millisecond)
+		return nil, nil
+	}
+	require.NoError(t, dag.Add(futures.Step{Name: "a", Run: slow}))
+	require.NoError(t, dag.Add(futures.Step{Name: "b", Run: slow}))
+	require.NoError(t, dag.Add(futures.Step{Name: "c", DependsOn: []string{"a", "b"}, Run: slow}))
+
+	run := dag.Run(nil)
+	_, err := run.Result()
+	require.NoError(t, err)
+
+	// a and b share the only worker, so one of them waits for the other.
+	rec := run.Record()
+	var waited time.Duration
+	for _, s := range rec.Report().Steps {
+		if s.Name != "c" {
+			waited = max(waited, s.QueueWait)
+		}
+	}
+	assert.GreaterOrEqual(t, waited, 15*time.Millisecond)
+
+	// c is ready once the later of a and b has finished.
+	c := rec.Steps[2]
+	last := rec.Steps[0].Finished
+	if rec.Steps[1].Finished.After(last) {
+		last = rec.Steps[1].Finished
+	}
+	assert.False(t, c.Ready.Before(last))
+	assert.Less(t, c.Ready.Sub(last), 10*time.Millisecond)
+	assert.False(t, c.Started.Before(c.Ready))
+}
@@ -0,0 +1,75 @@
+package futures
+
+import (
+	"sync"
+	"time"
+)
+
+// Debounce wraps fn so that rapid successive calls collapse into one: fn runs
+// only once no further call has arrived for d, with the argument of the last
+// call. Every call made within the same window receives the same future,
+// which settles with the outcome of that single run.
+func Debounce[A, T any](fn func(A) (T, error), d time.Duration, opts ...Option) func(A) *Future[T] {
+	o := buildOptions(opts)
+	db := &debouncer[A, T]{
+		fn:    fn,
+		delay: d,
+		clock: o.clock,
+		token: o.token,
+	}
+	return db.call
+}
+
+type debouncer[A, T any] struct {
+	fn    func(A) (T, error)
+	delay time.Duration
+	clock Clock
+	token *CancelToken
+
+	mu      sync.Mutex
+	pending *Promise[T]
+	arg     A
+	timer   Timer
+	gen     uint64 // incremented per call so stale timers can tell they lost
+}
+
+func (db *debouncer[A, T]) call(arg A) *Future[T] {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.pending == nil {
+		db.pending = NewPromise[T]()
+		attachToken(db.token, db.pending.future)
+	}
+	db.arg = arg
+	db.gen++
+
+	// Restart the quiet window.
+	if db.timer != nil {
+		db.timer.Stop()
+	}
+	gen := db.gen
+	db.timer = db.clock.AfterFunc(db.delay, func() {
+		db.fire(gen)
+	})
+	return db.pending.Future()
+}
+
+func (db *debouncer[A, T]) fire(gen uint64) {
+	db.mu.Lock()
+	if gen != db.gen {
+		// A later call restarted the window after this timer had fired.
+		db.mu.Unlock()
+		return
+	}
+	p, arg := db.pending, db.arg
+	db.pending, db.timer = nil, nil
+	db.mu.Unlock()
+
+	if p.future.State().settled() {
+		// Cancelled while waiting for the window to close
+		return
+	}
+	res, err := db.fn(arg)
+	p.future.complete(res, err)
+}
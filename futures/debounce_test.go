@@ -0,0 +1,36 @@
+package futures_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebounceRunsOnlyLastCall(t *testing.T) {
+	var runs atomic.Int32
+	search := futures.Debounce(func(q string) (string, error) {
+		runs.Add(1)
+		return "results for " + q, nil
+	}, 30*time.Millisecond)
+
+	first := search("g")
+	second := search("go")
+	third := search("gop")
+	assert.Same(t, first, third)
+	assert.Same(t, second, third)
+
+	result, err := first.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "results for gop", result)
+	assert.Equal(t, int32(1), runs.Load())
+
+	// After the window closes a new call starts a fresh future.
+	next := search("gopher")
+	assert.NotSame(t, first, next)
+	result, err = next.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "results for gopher", result)
+}
@@ -0,0 +1,25 @@
+package futures
+
+// NewDirectExecutor creates an executor without workers: every task runs
+// inline on the goroutine that submits it. Submit returns an already settled
+// future, and stages chained with Then run on the goroutine that settles
+// their parent, so a chain built on a direct executor completes before the
+// call that started it returns, in a deterministic order. This makes it a
+// drop-in replacement for a pooled executor in unit tests.
+//
+// Only futures created through the executor run inline. Futures created with
+// NewFuture still get goroutines of their own.
+func NewDirectExecutor(opts ...ExecutorOption) *Executor {
+	direct := func(e *Executor) { e.direct = true }
+	return NewExecutor(1, 0, append([]ExecutorOption{direct}, opts...)...)
+}
+
+func (e *Executor) runDirect(t execTask) {
+	defer func() {
+		e.mu.Lock()
+		e.busy--
+		e.checkDrainedLocked()
+		e.mu.Unlock()
+	}()
+	t.run()
+}
@@ -0,0 +1,40 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectExecutorRunsInline(t *testing.T) {
+	exec := futures.NewDirectExecutor(futures.WithMinIdle(2))
+
+	var order []string
+	f := futures.Submit(exec, func() (int, error) {
+		order = append(order, "task")
+		return 1, nil
+	})
+	f.OnSuccess(func(int) { order = append(order, "callback") })
+	next := futures.Then(f, func(v int) (int, error) {
+		order = append(order, "then")
+		return v + 1, nil
+	})
+
+	assert.True(t, next.IsDone())
+	v, err, ok := next.TryResult()
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, []string{"task", "callback", "then"}, order)
+	assert.Zero(t, exec.Workers())
+}
+
+func TestDirectExecutorShutdown(t *testing.T) {
+	exec := futures.NewDirectExecutor()
+	assert.NoError(t, exec.Shutdown(context.Background()))
+
+	_, err := futures.Submit(exec, func() (int, error) { return 1, nil }).Result()
+	assert.ErrorIs(t, err, futures.ErrExecutorShutdown)
+}
@@ -0,0 +1,54 @@
+package futures
+
+// Dispatcher decides where callbacks registered with OnSuccessOn and its
+// siblings run. Callbacks registered without one run inline, on the
+// goroutine that settles the future, or asynchronously if they are
+// registered once it has settled.
+type Dispatcher interface {
+	Dispatch(fn func())
+}
+
+// DispatchFunc adapts a function to a Dispatcher, for example to marshal
+// callbacks onto a UI or game loop:
+//
+//	f.OnSuccessOn(futures.DispatchFunc(loop.Post), render)
+type DispatchFunc func(fn func())
+
+// Dispatch calls d(fn).
+func (d DispatchFunc) Dispatch(fn func()) {
+	d(fn)
+}
+
+// Inline runs callbacks on the settling goroutine, like callbacks registered
+// without a Dispatcher.
+var Inline Dispatcher = DispatchFunc(func(fn func()) { fn() })
+
+// Dispatch runs fn on one of the executor's workers, making it usable as a
+// callback pool. It never blocks the settling goroutine on a full queue. If
+// the executor is shut down, fn runs inline rather than being lost.
+func (e *Executor) Dispatch(fn func()) {
+	if err := e.submit(execTask{run: fn}, false); err != nil {
+		fn()
+	}
+}
+
+// OnSuccessOn is OnSuccess with cb run by d.
+func (f *Future[T]) OnSuccessOn(d Dispatcher, cb func(T)) *Subscription {
+	return f.OnSuccess(func(v T) {
+		d.Dispatch(func() { cb(v) })
+	})
+}
+
+// OnFailureOn is OnFailure with cb run by d.
+func (f *Future[T]) OnFailureOn(d Dispatcher, cb func(error)) *Subscription {
+	return f.OnFailure(func(err error) {
+		d.Dispatch(func() { cb(err) })
+	})
+}
+
+// OnCompleteOn is OnComplete with cb run by d.
+func (f *Future[T]) OnCompleteOn(d Dispatcher, cb func(T, error)) *Subscription {
+	return f.OnComplete(func(v T, err error) {
+		d.Dispatch(func() { cb(v, err) })
+	})
+}
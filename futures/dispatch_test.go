@@ -0,0 +1,31 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallbacksRunOnDispatcher(t *testing.T) {
+	loop := make(chan func(), 2)
+	post := futures.DispatchFunc(func(fn func()) { loop <- fn })
+
+	p := futures.NewPromise[int]()
+	var got int
+	p.Future().OnSuccessOn(post, func(v int) { got = v })
+	p.Complete(7)
+
+	// Nothing ran on the settling goroutine; the loop runs the callback.
+	assert.Equal(t, 0, got)
+	(<-loop)()
+	assert.Equal(t, 7, got)
+}
+
+func TestCallbacksRunOnExecutor(t *testing.T) {
+	e := futures.NewExecutor(1, 1)
+	done := make(chan error, 1)
+	futures.Failed[int](errors.New("boom")).OnFailureOn(e, func(err error) { done <- err })
+	assert.EqualError(t, <-done, "boom")
+}
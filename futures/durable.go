@@ -0,0 +1,256 @@
+package futures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DurableRecord is the outcome of a durable task, as kept in a DurableStore.
+type DurableRecord struct {
+	Key       string          `json:"key"`
+	Value     json.RawMessage `json:"value,omitempty"` // JSON-encoded result, if the task succeeded
+	Error     string          `json:"error,omitempty"` // Error message, if it failed
+	SettledAt time.Time       `json:"settled_at"`
+}
+
+// DurableStore persists the outcomes of durable tasks, keyed by task
+// identity. File, SQL or key-value implementations can be plugged in; they
+// must be safe for concurrent use.
+type DurableStore interface {
+	// Get returns the record stored for key, or false if there is none.
+	Get(key string) (DurableRecord, bool, error)
+	Put(r DurableRecord) error
+	Delete(key string) error
+}
+
+// StoredError is the error of a durable task whose failure was replayed
+// from its DurableStore. Only the message of the original error survives.
+type StoredError struct {
+	Key     string // Identity of the task
+	Message string // Message of the error the task failed with
+}
+
+func (e *StoredError) Error() string {
+	return e.Message
+}
+
+// DurableExecutor runs tasks identified by a key and records their outcome
+// in a DurableStore, so that results survive process restarts: submitting a
+// task whose outcome is stored returns it without running the task again.
+// Results are stored as JSON, so they must survive a round trip through
+// encoding/json.
+//
+// The keys double as idempotency keys: give each side-effecting operation a
+// key derived from the request it serves, such as a message ID from an
+// at-least-once queue, and duplicate deliveries resolve to the one execution
+// instead of repeating its effects. SubmitDurableRetry keeps the retries of
+// an operation within that execution. For deduplication within one process,
+// a MemoryDurableStore is enough.
+type DurableExecutor struct {
+	store DurableStore
+	exec  *Executor
+
+	mu       sync.Mutex
+	inflight map[string]any // *Future[T] of the running task for each key
+}
+
+// NewDurableExecutor creates a durable executor recording outcomes in store
+// and running tasks on exec, or on goroutines of their own if exec is nil.
+func NewDurableExecutor(store DurableStore, exec *Executor) *DurableExecutor {
+	return &DurableExecutor{store: store, exec: exec, inflight: make(map[string]any)}
+}
+
+// SubmitDurable returns a started future for the task identified by key. If
+// the store holds an outcome for key, the future settles with it right away:
+// with the stored value, or with a *StoredError. If the task is already
+// running, its future is returned. Otherwise task runs and its outcome is
+// stored before the future settles; if storing fails, the future rejects
+// with that error as well. Cancelled tasks are not recorded, so submitting
+// them again starts afresh.
+func SubmitDurable[T any](d *DurableExecutor, key string, task func() (T, error)) *Future[T] {
+	return submitDurable(d, key, func(*Future[T]) (T, error) { return task() })
+}
+
+// SubmitDurableRetry is SubmitDurable for a task retried until it succeeds or
+// policy gives up, as with Retry. The attempts form one execution: duplicate
+// submissions while they run share its future, and only the final outcome is
+// stored, so a task that succeeded on a later attempt is not run again, and
+// one that gave up is replayed as failed rather than retried afresh. The
+// pause between attempts holds the executor's worker, if there is one.
+func SubmitDurableRetry[T any](d *DurableExecutor, key string, task func() (T, error), policy BackoffPolicy) *Future[T] {
+	return submitDurable(d, key, func(f *Future[T]) (T, error) { return f.retry(task, policy) })
+}
+
+func submitDurable[T any](d *DurableExecutor, key string, task func(f *Future[T]) (T, error)) *Future[T] {
+	d.mu.Lock()
+	if running, ok := d.inflight[key]; ok {
+		d.mu.Unlock()
+		if f, ok := running.(*Future[T]); ok {
+			return f
+		}
+		return Failed[T](fmt.Errorf("futures: durable task %q is running with another result type", key))
+	}
+	rec, found, err := d.store.Get(key)
+	if err != nil || found {
+		d.mu.Unlock()
+		if err != nil {
+			return Failed[T](fmt.Errorf("futures: loading durable task %q: %w", key, err))
+		}
+		return replay[T](rec)
+	}
+
+	var f *Future[T]
+	done := func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.inflight[key] == any(f) {
+			delete(d.inflight, key)
+		}
+	}
+	f = newFuture(func() (T, error) {
+		// Once the outcome is stored, later submissions replay it.
+		defer done()
+		res, err := task(f)
+		if errors.Is(err, ErrCancelled) {
+			return res, err
+		}
+		if putErr := d.record(key, res, err); putErr != nil {
+			return res, errors.Join(err, fmt.Errorf("futures: recording durable task %q: %w", key, putErr))
+		}
+		return res, err
+	}, d.exec, options{})
+	d.inflight[key] = f
+	d.mu.Unlock()
+
+	// A future cancelled before its task runs never gets to the task's done.
+	f.whenSettled(done)
+	f.Start()
+	return f
+}
+
+// Forget removes the stored outcome of key, so that the task runs again when
+// next submitted.
+func (d *DurableExecutor) Forget(key string) error {
+	return d.store.Delete(key)
+}
+
+func (d *DurableExecutor) record(key string, res any, err error) error {
+	rec := DurableRecord{Key: key, SettledAt: clockFor(d.exec, options{}).Now()}
+	if err != nil {
+		rec.Error = err.Error()
+	} else {
+		data, encErr := json.Marshal(res)
+		if encErr != nil {
+			return encErr
+		}
+		rec.Value = data
+	}
+	return d.store.Put(rec)
+}
+
+// replay returns a settled future for a stored outcome.
+func replay[T any](rec DurableRecord) *Future[T] {
+	if rec.Error != "" {
+		return Failed[T](&StoredError{Key: rec.Key, Message: rec.Error})
+	}
+	var v T
+	if err := json.Unmarshal(rec.Value, &v); err != nil {
+		return Failed[T](fmt.Errorf("futures: decoding durable task %q: %w", rec.Key, err))
+	}
+	return Resolved(v)
+}
+
+// MemoryDurableStore is a DurableStore kept in memory, mainly useful for
+// tests.
+type MemoryDurableStore struct {
+	mu      sync.Mutex
+	records map[string]DurableRecord
+}
+
+// NewMemoryDurableStore creates an empty in-memory store.
+func NewMemoryDurableStore() *MemoryDurableStore {
+	return &MemoryDurableStore{records: make(map[string]DurableRecord)}
+}
+
+// Get returns the record stored for key.
+func (s *MemoryDurableStore) Get(key string) (DurableRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[key]
+	return r, ok, nil
+}
+
+// Put stores or replaces a record.
+func (s *MemoryDurableStore) Put(r DurableRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[r.Key] = r
+	return nil
+}
+
+// Delete removes a record; deleting an unknown record is not an error.
+func (s *MemoryDurableStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// FileDurableStore is a DurableStore keeping one JSON file per record in a
+// directory.
+type FileDurableStore struct {
+	dir string
+}
+
+// NewFileDurableStore creates a store in dir, creating the directory if
+// needed.
+func NewFileDurableStore(dir string) (*FileDurableStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileDurableStore{dir: dir}, nil
+}
+
+// Get returns the record stored for key.
+func (s *FileDurableStore) Get(key string) (DurableRecord, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return DurableRecord{}, false, nil
+	}
+	if err != nil {
+		return DurableRecord{}, false, err
+	}
+	var r DurableRecord
+	if err := json.Unmarshal(data, &r); err != nil {
+		return DurableRecord{}, false, fmt.Errorf("futures: decoding %s: %w", s.path(key), err)
+	}
+	return r, true, nil
+}
+
+// Put stores or replaces a record.
+func (s *FileDurableStore) Put(r DurableRecord) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.dir, s.path(r.Key), data)
+}
+
+// Delete removes a record; deleting an unknown record is not an error.
+func (s *FileDurableStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileDurableStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".json")
+}
@@ -0,0 +1,136 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/futurestest"
+	"github.com/stretchr/testify/assert"
+)
+
+type invoice struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+func TestDurableTaskSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	var runs atomic.Int32
+	charge := func() (invoice, error) {
+		runs.Add(1)
+		return invoice{ID: "inv-1", Total: 42}, nil
+	}
+
+	store, err := futures.NewFileDurableStore(dir)
+	assert.NoError(t, err)
+	d := futures.NewDurableExecutor(store, nil)
+	v, err := futures.SubmitDurable(d, "charge/order-9", charge).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, invoice{ID: "inv-1", Total: 42}, v)
+
+	// A new executor over the same directory stands in for a restart.
+	store, err = futures.NewFileDurableStore(dir)
+	assert.NoError(t, err)
+	d = futures.NewDurableExecutor(store, nil)
+	again := futures.SubmitDurable(d, "charge/order-9", charge)
+	assert.True(t, again.IsDone())
+	v, err = again.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, invoice{ID: "inv-1", Total: 42}, v)
+	assert.Equal(t, int32(1), runs.Load())
+
+	assert.NoError(t, d.Forget("charge/order-9"))
+	_, err = futures.SubmitDurable(d, "charge/order-9", charge).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestDurableExecutorDeduplicatesAndReplaysFailures(t *testing.T) {
+	d := futures.NewDurableExecutor(futures.NewMemoryDurableStore(), nil)
+
+	release := make(chan struct{})
+	var runs atomic.Int32
+	slow := func() (int, error) {
+		runs.Add(1)
+		<-release
+		return 0, errors.New("card declined")
+	}
+	first := futures.SubmitDurable(d, "k", slow)
+	second := futures.SubmitDurable(d, "k", slow)
+	assert.Same(t, first, second)
+	close(release)
+	_, err := first.Result()
+	assert.EqualError(t, err, "card declined")
+
+	_, err = futures.SubmitDurable(d, "k", slow).Result()
+	var stored *futures.StoredError
+	assert.ErrorAs(t, err, &stored)
+	assert.Equal(t, "card declined", stored.Message)
+	assert.Equal(t, int32(1), runs.Load())
+
+	// Cancelled tasks are not recorded.
+	cancelled := futures.SubmitDurable(d, "c", func() (int, error) { return 0, futures.ErrCancelled })
+	_, err = cancelled.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	v, err := futures.SubmitDurable(d, "c", func() (int, error) { return 7, nil }).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 7, v)
+}
+
+func TestSubmitDurableRetryRunsOneExecutionPerKey(t *testing.T) {
+	d := futures.NewDurableExecutor(futures.NewMemoryDurableStore(), nil)
+	policy := futures.MaxAttempts(futures.ConstantBackoff(5*time.Millisecond), 5)
+
+	var runs atomic.Int32
+	flaky := func() (string, error) {
+		if runs.Add(1) < 3 {
+			return "", errors.New("gateway timeout")
+		}
+		return "sent", nil
+	}
+	first := futures.SubmitDurableRetry(d, "msg-7", flaky, policy)
+	// A redelivery while the first delivery is still retrying joins it.
+	dup := futures.SubmitDurableRetry(d, "msg-7", flaky, policy)
+	assert.Same(t, first, dup)
+	v, err := first.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "sent", v)
+
+	// A later redelivery replays the outcome of the final attempt.
+	v, err = futures.SubmitDurableRetry(d, "msg-7", flaky, policy).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "sent", v)
+	assert.Equal(t, int32(3), runs.Load())
+
+	var failures atomic.Int32
+	failing := func() (string, error) {
+		failures.Add(1)
+		return "", errors.New("mailbox full")
+	}
+	_, err = futures.SubmitDurableRetry(d, "msg-8", failing, futures.MaxAttempts(futures.ConstantBackoff(time.Millisecond), 2)).Result()
+	assert.Error(t, err)
+	_, err = futures.SubmitDurableRetry(d, "msg-8", failing, policy).Result()
+	var stored *futures.StoredError
+	assert.ErrorAs(t, err, &stored)
+	assert.Equal(t, int32(2), failures.Load())
+}
+
+func TestDurableRecordsSettleTimeOnExecutorClock(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := futurestest.NewClock(start)
+	exec := futures.NewExecutor(1, 1, futures.WithExecutorClock(clock))
+	defer exec.Shutdown(context.Background())
+	store := futures.NewMemoryDurableStore()
+	d := futures.NewDurableExecutor(store, exec)
+
+	_, err := futures.SubmitDurable(d, "job", func() (int, error) { return 1, nil }).Result()
+	assert.NoError(t, err)
+	rec, ok, err := store.Get("job")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, rec.SettledAt.Equal(start), "settle time comes from the executor's clock")
+}
@@ -0,0 +1,65 @@
+package futures
+
+import "sync"
+
+// NewEmittingFuture creates a future whose task can publish intermediate
+// values with emit before returning its final result, such as the pages of a
+// paginated fetch. The intermediates arrive on the returned stream, which
+// buffers up to buffer of them; the final value is read with Result as usual.
+// The stream is closed once the future settles, with the future's error if it
+// failed.
+//
+// emit blocks while the buffer is full. A consumer that has no use for the
+// intermediates should Stop the stream, after which emit drops them and
+// returns ErrStreamStopped, as it also does once the future has settled.
+func NewEmittingFuture[T, P any](buffer int, task func(emit func(P) error) (T, error), opts ...Option) (*Future[T], *Stream[P]) {
+	e := &emitter[P]{out: NewStream[P](buffer)}
+	f := newSelfFuture(buildOptions(opts), func(f *Future[T]) (T, error) {
+		return task(func(v P) error { return e.emit(v, f.settled) })
+	})
+	f.whenSettled(func() {
+		_, err := f.outcome()
+		e.close(err)
+	})
+	return f, e.out
+}
+
+// emitter feeds a stream from a task that may still be emitting when its
+// future settles.
+type emitter[P any] struct {
+	mu     sync.Mutex // serialises emitting with closing the stream
+	closed bool
+	out    *Stream[P]
+}
+
+func (e *emitter[P]) emit(v P, settled func() <-chan struct{}) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return ErrStreamStopped
+	}
+	done := settled()
+	select {
+	case <-e.out.stopped:
+		return ErrStreamStopped
+	case <-done:
+		return ErrStreamStopped
+	default:
+	}
+
+	select {
+	case e.out.values <- v:
+		return nil
+	case <-e.out.stopped:
+		return ErrStreamStopped
+	case <-done:
+		return ErrStreamStopped
+	}
+}
+
+func (e *emitter[P]) close(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	e.out.Close(err)
+}
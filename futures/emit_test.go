@@ -0,0 +1,69 @@
+package futures_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmittingFutureStreamsPages(t *testing.T) {
+	f, pages := futures.NewEmittingFuture(0, func(emit func([]int) error) (int, error) {
+		total := 0
+		for page := 0; page < 3; page++ {
+			items := []int{page * 2, page*2 + 1}
+			if err := emit(items); err != nil {
+				return 0, err
+			}
+			total += len(items)
+		}
+		return total, nil
+	})
+	f.Start()
+
+	var got [][]int
+	for page, err := range pages.All() {
+		assert.NoError(t, err)
+		got = append(got, page)
+	}
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4, 5}}, got)
+	total, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 6, total)
+}
+
+func TestEmittingFutureClosesStreamWithFailure(t *testing.T) {
+	f, s := futures.NewEmittingFuture(1, func(emit func(string) error) (string, error) {
+		_ = emit("partial")
+		return "", fmt.Errorf("page 2: timeout")
+	})
+	f.Start()
+
+	v, err := s.Recv(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "partial", v)
+	_, err = s.Recv(context.Background())
+	assert.EqualError(t, err, "page 2: timeout")
+	_, err = f.Result()
+	assert.EqualError(t, err, "page 2: timeout")
+}
+
+func TestEmittingFutureUnblocksEmitOnCancel(t *testing.T) {
+	blocked := make(chan struct{})
+	emitErr := make(chan error, 1)
+	f, s := futures.NewEmittingFuture(0, func(emit func(int) error) (int, error) {
+		close(blocked)
+		err := emit(1) // nobody reads
+		emitErr <- err
+		return 0, err
+	})
+	f.Start()
+	<-blocked
+
+	f.Cancel()
+	assert.ErrorIs(t, <-emitErr, futures.ErrStreamStopped)
+	_, err := s.Recv(context.Background())
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
@@ -0,0 +1,175 @@
+package futures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCancelled is the error a future settles with when it is cancelled before
+// its task produced a result.
+var ErrCancelled = errors.New("futures: future cancelled")
+
+// ErrWeightTooLarge is returned when a semaphore is asked for more weight than
+// its total capacity, which could never be granted.
+var ErrWeightTooLarge = errors.New("futures: requested weight exceeds semaphore size")
+
+// ErrMissingKey is returned to a coalesced caller whose key was absent from
+// the batch response.
+var ErrMissingKey = errors.New("futures: key missing from batch result")
+
+// ErrParked is returned when a task exhausted its in-memory attempts and was
+// handed to a durable retry store for later re-submission.
+var ErrParked = errors.New("futures: task parked for durable retry")
+
+// ErrNoFutures is returned by combinators such as Race that need at least one
+// input future.
+var ErrNoFutures = errors.New("futures: no futures given")
+
+// ErrExecutorShutdown is returned for work submitted to an executor that is
+// shutting down, and for queued work it abandoned when its shutdown deadline
+// expired.
+var ErrExecutorShutdown = errors.New("futures: executor is shut down")
+
+// ErrQueueFull is returned for work submitted to an executor whose queue is
+// full, when it is configured to shed load rather than block; see
+// WithOverflow. Tasks dropped from the queue to make room for newer ones fail
+// with it too.
+var ErrQueueFull = errors.New("futures: executor queue is full")
+
+// ErrNilFuture is returned by Flatten when the outer future resolves with a
+// nil future, and by FlatMap when its function returns one.
+var ErrNilFuture = errors.New("futures: nil future")
+
+// ErrChannelClosed is returned by FromChannel futures when the channel is
+// closed before delivering a value.
+var ErrChannelClosed = errors.New("futures: channel closed without a value")
+
+// ErrTimeout is returned by ResultTimeout when the future does not settle in
+// time.
+var ErrTimeout = errors.New("futures: timed out waiting for result")
+
+// ErrStreamStopped is returned by Stream.Send once the consumer has stopped
+// reading.
+var ErrStreamStopped = errors.New("futures: stream stopped by consumer")
+
+// ErrStageTimeout is returned by a stage given a deadline with WithTimeout
+// that did not finish in time. The error names the stage that expired.
+var ErrStageTimeout = errors.New("futures: stage timed out")
+
+// ErrDeadlineExceeded is returned by a future given a deadline with
+// WithDeadline that had not settled by then. The error names the future and
+// the deadline.
+var ErrDeadlineExceeded = errors.New("futures: deadline exceeded")
+
+// ErrQuorumUnreachable is returned by FirstN once too many of its inputs have
+// failed for the required number to succeed.
+var ErrQuorumUnreachable = errors.New("futures: not enough futures can succeed")
+
+// ErrCircuitOpen is returned by CircuitBreaker.Do while the breaker is open
+// and rejecting calls without running them.
+var ErrCircuitOpen = errors.New("futures: circuit breaker is open")
+
+// StageError reports which stage of a chain raised an error; failures passed
+// on from earlier stages keep the stage that raised them. It wraps the
+// original error, so errors.Is and errors.As see through it.
+type StageError struct {
+	Stage int    // Position in the chain, 0 for the root
+	Name  string // Name given with ThenNamed, if any
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("stage %d (%s): %v", e.Stage, e.Name, e.Err)
+	}
+	return fmt.Sprintf("stage %d: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is the error a future rejects with when its task, or a stage
+// chained onto it, panics. The panic does not crash the program.
+type PanicError struct {
+	Value any    // The value passed to panic
+	Stack []byte // The panicking goroutine's stack trace
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("futures: task panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, so that errors.Is and
+// errors.As see through the panic.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// AggregateError collects the failures of several futures, for combinators
+// such as All, AllJoined and Any. errors.Is and errors.As match any of them,
+// and Indices tells which inputs they came from.
+type AggregateError struct {
+	Errors  []error // In input order
+	Indices []int   // Input position of each error in Errors
+}
+
+func (e *AggregateError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("futures: %d errors: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *AggregateError) Unwrap() []error {
+	return e.Errors
+}
+
+// ErrorAt returns the error of the input at position i, or nil if that input
+// did not fail.
+func (e *AggregateError) ErrorAt(i int) error {
+	for j, idx := range e.Indices {
+		if idx == i {
+			return e.Errors[j]
+		}
+	}
+	return nil
+}
+
+// PendingError is returned by Results when its context ends before every
+// input has settled. It wraps the context's error, so errors.Is matches
+// context.Canceled or context.DeadlineExceeded.
+type PendingError struct {
+	Pending []int // Input positions of the futures still running, in order
+	Err     error // The context's error
+}
+
+func (e *PendingError) Error() string {
+	return fmt.Sprintf("futures: %d of the futures still pending: %v", len(e.Pending), e.Err)
+}
+
+func (e *PendingError) Unwrap() error {
+	return e.Err
+}
+
+// aggregate returns an AggregateError for the non-nil errors in errs, indexed
+// by their position, or nil if there are none.
+func aggregate(errs []error) error {
+	agg := &AggregateError{}
+	for i, err := range errs {
+		if err != nil {
+			agg.Errors = append(agg.Errors, err)
+			agg.Indices = append(agg.Indices, i)
+		}
+	}
+	if len(agg.Errors) == 0 {
+		return nil
+	}
+	return agg
+}
@@ -0,0 +1,62 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanickingTaskRejectsWithPanicError(t *testing.T) {
+	boom := errors.New("boom")
+	f := futures.NewFuture(func() (int, error) { panic(boom) })
+	next := futures.Then(f, func(n int) (int, error) { return n, nil })
+
+	_, err := next.Result()
+	var panicErr *futures.PanicError
+	if assert.ErrorAs(t, err, &panicErr) {
+		assert.Equal(t, boom, panicErr.Value)
+		assert.NotEmpty(t, panicErr.Stack)
+	}
+	assert.ErrorIs(t, err, boom)
+
+	// A panicking stage is attributed to that stage.
+	_, err = futures.Then(futures.Resolved(1), func(int) (int, error) { panic("bad") }).Result()
+	assert.ErrorAs(t, err, &panicErr)
+	assert.ErrorContains(t, err, "stage 1: futures: task panicked: bad")
+}
+
+func TestAggregateErrorListsEveryFailure(t *testing.T) {
+	a, b := errors.New("a"), errors.New("b")
+	_, err := futures.AllJoined(futures.Failed[int](a), futures.Resolved(1), futures.Failed[int](b)).Result()
+
+	var agg *futures.AggregateError
+	if assert.ErrorAs(t, err, &agg) {
+		assert.Equal(t, []error{a, b}, agg.Errors)
+		assert.Equal(t, []int{0, 2}, agg.Indices)
+		assert.Equal(t, b, agg.ErrorAt(2))
+		assert.Nil(t, agg.ErrorAt(1))
+	}
+	assert.EqualError(t, err, "futures: 2 errors: a; b")
+}
+
+func TestAggregateErrorRecordsFailedInput(t *testing.T) {
+	boom := errors.New("boom")
+	_, err := futures.All(futures.Resolved(1), futures.Failed[int](boom)).Result()
+
+	var agg *futures.AggregateError
+	if assert.ErrorAs(t, err, &agg) {
+		assert.Equal(t, []int{1}, agg.Indices)
+	}
+	assert.ErrorIs(t, err, boom)
+	assert.EqualError(t, err, "boom")
+
+	rs, err := futures.AllSettled(futures.Failed[int](boom), futures.Resolved(2)).Result()
+	assert.NoError(t, err)
+	err = futures.AggregateResults(rs)
+	if assert.ErrorAs(t, err, &agg) {
+		assert.Equal(t, boom, agg.ErrorAt(0))
+	}
+	assert.NoError(t, futures.AggregateResults(rs[1:]))
+}
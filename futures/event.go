@@ -0,0 +1,67 @@
+package futures
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Event is a manual-reset event: once Set, every current and future waiter is
+// released until Reset is called. The zero value is an unset event.
+type Event struct {
+	mu      sync.Mutex
+	set     bool
+	waiters list.List // of *Promise[struct{}]
+}
+
+// Set signals the event, releasing all waiters together.
+func (e *Event) Set() {
+	e.mu.Lock()
+	e.set = true
+	var waiters []*Promise[struct{}]
+	for elem := e.waiters.Front(); elem != nil; elem = elem.Next() {
+		waiters = append(waiters, elem.Value.(*Promise[struct{}]))
+	}
+	e.waiters.Init()
+	e.mu.Unlock()
+
+	for _, p := range waiters {
+		p.Complete(struct{}{})
+	}
+}
+
+// Reset clears the event so that subsequent waiters block until the next Set.
+func (e *Event) Reset() {
+	e.mu.Lock()
+	e.set = false
+	e.mu.Unlock()
+}
+
+// IsSet reports whether the event is currently signalled.
+func (e *Event) IsSet() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.set
+}
+
+// Wait returns a future that resolves once the event is set. It is already
+// resolved if the event is set at the time of the call.
+func (e *Event) Wait() *Future[struct{}] {
+	p := NewPromise[struct{}]()
+
+	e.mu.Lock()
+	if e.set {
+		e.mu.Unlock()
+		p.Complete(struct{}{})
+		return p.Future()
+	}
+	elem := e.waiters.PushBack(p)
+	e.mu.Unlock()
+
+	// Cancelled waiters are dropped instead of lingering until the next Set.
+	p.Future().OnFailure(func(error) {
+		e.mu.Lock()
+		e.waiters.Remove(elem)
+		e.mu.Unlock()
+	})
+	return p.Future()
+}
@@ -0,0 +1,29 @@
+package futures_test
+
+import (
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventReleasesAllWaiters(t *testing.T) {
+	var ev futures.Event
+
+	a := ev.Wait()
+	b := ev.Wait()
+	assert.Equal(t, futures.Pending, a.State())
+
+	ev.Set()
+	_, errA := a.Result()
+	_, errB := b.Result()
+	assert.NoError(t, errA)
+	assert.NoError(t, errB)
+
+	// While set, new waiters resolve immediately.
+	assert.Equal(t, futures.Fulfilled, ev.Wait().State())
+
+	ev.Reset()
+	assert.False(t, ev.IsSet())
+	assert.Equal(t, futures.Pending, ev.Wait().State())
+}
@@ -0,0 +1,356 @@
+package futures
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// DefaultIdleTimeout is how long an executor worker waits for work before it
+// retires, unless configured otherwise with WithIdleTimeout.
+const DefaultIdleTimeout = 30 * time.Second
+
+// Executor is a bounded pool of worker goroutines running futures. Workers
+// are started on demand up to the configured maximum and retire after being
+// idle for a while; a minimum number of idle workers can be kept alive to
+// avoid cold starts.
+//
+// Futures chained with Then from a future running on an executor run on the
+// same executor. A chained stage is only queued once its parent has settled,
+// so waiting stages never hold a worker.
+type Executor struct {
+	maxWorkers  int
+	minIdle     int
+	idleTimeout time.Duration
+	queue       chan struct{} // one token per task in pending, bounding the queue
+	metrics     MetricsCollector
+	logger      *slog.Logger
+	middleware  []Middleware
+	clock       Clock
+	limiter     *RateLimiter
+	name        string // Set for pools, see Pool
+	poolCfg     map[string]poolConfig
+	drained     chan struct{} // closed once shut down with no work left
+	direct      bool          // run tasks inline in submit; see NewDirectExecutor
+	overflow    OverflowPolicy
+	slowAfter   time.Duration // see OnSlowTask
+	onSlow      func(SlowTask)
+	fifo        bool // ignore priorities and deadlines; see NewEventLoop
+	autoscale   *autoscaler
+	lockThread  bool       // see WithLockedOSThread
+	reportMu    sync.Mutex // orders reportWorkers calls
+
+	mu        sync.Mutex
+	workers   int
+	busy      int
+	waiting   int // tasks accepted but not yet picked up by a worker
+	closed    bool
+	aborting  bool
+	isDrained bool
+	pools     map[string]*Executor
+	pending   taskQueue         // tasks not yet picked up, in priority order
+	running   map[uint64]func() // cancel funcs of running tasks, by seq
+}
+
+type execTask struct {
+	run      func()
+	reject   func(error) // called instead of run when the task is abandoned; may be nil
+	cancel   func()      // called if the task is still running when shutdown gives up; may be nil
+	priority int         // higher runs first, see SubmitPriority
+	deadline time.Time   // earlier runs first among equal priorities; zero if none
+	seq      uint64      // submission order, breaking remaining ties
+	queued   time.Time   // when it was submitted; only set when autoscaling
+}
+
+// ExecutorOption configures an Executor.
+type ExecutorOption func(*Executor)
+
+// WithMinIdle keeps at least n workers alive even when there is no work, and
+// starts them when the executor is created.
+func WithMinIdle(n int) ExecutorOption {
+	return func(e *Executor) {
+		e.minIdle = n
+	}
+}
+
+// WithIdleTimeout sets how long a worker waits for work before it retires.
+func WithIdleTimeout(d time.Duration) ExecutorOption {
+	return func(e *Executor) {
+		e.idleTimeout = d
+	}
+}
+
+// NewExecutor creates an executor running at most maxWorkers tasks at a time
+// and holding up to queueSize further tasks before submitters block.
+func NewExecutor(maxWorkers, queueSize int, opts ...ExecutorOption) *Executor {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	e := &Executor{
+		maxWorkers:  maxWorkers,
+		idleTimeout: DefaultIdleTimeout,
+		queue:       make(chan struct{}, queueSize),
+		drained:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.minIdle > e.maxWorkers {
+		e.minIdle = e.maxWorkers
+	}
+	if e.autoscale != nil {
+		e.autoscale.limit = max(e.minIdle, 1)
+	}
+	if e.lockThread && e.overflow == OverflowCallerRuns {
+		e.overflow = OverflowBlock
+	}
+	e.Prestart(e.minIdle)
+	return e
+}
+
+// Submit returns a future for task running on the executor. It blocks while
+// the executor's queue is full. If the executor is shut down, the future
+// rejects with ErrExecutorShutdown.
+func Submit[T any](e *Executor, task func() (T, error)) *Future[T] {
+	f := newFuture(task, e, options{})
+	f.Start()
+	return f
+}
+
+// Go runs fn on a worker, blocking while the queue is full. It returns
+// ErrExecutorShutdown if the executor no longer accepts work. Functions still
+// queued when a shutdown deadline expires are dropped.
+func (e *Executor) Go(fn func()) error {
+	return e.submit(execTask{run: fn}, true)
+}
+
+// submit queues t. Unless block is set it never waits for queue space,
+// handing the send to a goroutine instead; this is used from workers, which
+// must not block on their own queue.
+func (e *Executor) submit(t execTask, block bool) error {
+	if e.autoscale != nil {
+		t.queued = clockFor(e, options{}).Now()
+	}
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return ErrExecutorShutdown
+	}
+	if e.direct {
+		e.busy++
+		e.mu.Unlock()
+		e.runDirect(t)
+		return nil
+	}
+	if e.fifo {
+		t.priority, t.deadline = 0, time.Time{}
+	}
+	if e.overflow != OverflowBlock && e.fullLocked() {
+		switch e.overflow {
+		case OverflowReject:
+			e.mu.Unlock()
+			return ErrQueueFull
+		case OverflowCallerRuns:
+			e.busy++
+			e.mu.Unlock()
+			e.runDirect(t)
+			return nil
+		case OverflowDropOldest:
+			if old, ok := e.pending.removeOldest(); ok {
+				// t takes over the slot, and the token, of the dropped task.
+				e.pending.push(t)
+				e.mu.Unlock()
+				if old.reject != nil {
+					old.reject(ErrQueueFull)
+				}
+				return nil
+			}
+		}
+	}
+	e.waiting++
+	e.pending.push(t)
+	// Start a worker if the idle ones cannot absorb the waiting work.
+	spawned := e.workers-e.busy < e.waiting && e.workers < e.maxWorkersLocked()
+	if spawned {
+		e.workers++
+		go e.worker()
+	}
+	e.mu.Unlock()
+	if spawned {
+		e.reportWorkers()
+	}
+
+	// The task is already queued; the token only claims its slot, so a task
+	// whose submitter is blocked here may still be picked up first.
+	if block {
+		e.queue <- struct{}{}
+		return nil
+	}
+	select {
+	case e.queue <- struct{}{}:
+	default:
+		go func() { e.queue <- struct{}{} }()
+	}
+	return nil
+}
+
+// Prestart starts up to n additional idle workers, bounded by the maximum
+// pool size, and returns how many were started.
+func (e *Executor) Prestart(n int) int {
+	e.mu.Lock()
+	started := 0
+	for !e.closed && !e.direct && started < n && e.workers < e.maxWorkers {
+		e.workers++
+		started++
+		go e.worker()
+	}
+	e.mu.Unlock()
+	if started > 0 {
+		e.reportWorkers()
+	}
+	return started
+}
+
+// Workers returns the number of live workers.
+func (e *Executor) Workers() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.workers
+}
+
+// Shutdown stops the executor from accepting new work and waits for queued
+// and running tasks to finish. If ctx ends first, futures still queued are
+// rejected with ErrExecutorShutdown instead of being run, futures still
+// running are cancelled, and ctx.Err() is returned. Work submitted after
+// Shutdown is called fails with ErrExecutorShutdown. The executor's pools are
+// shut down too.
+func (e *Executor) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	e.closed = true
+	e.checkDrainedLocked()
+	e.mu.Unlock()
+
+	poolErr := e.shutdownPools(ctx)
+	select {
+	case <-e.drained:
+		return poolErr
+	case <-ctx.Done():
+		e.mu.Lock()
+		e.aborting = true
+		cancels := make([]func(), 0, len(e.running))
+		for _, cancel := range e.running {
+			cancels = append(cancels, cancel)
+		}
+		e.mu.Unlock()
+		for _, cancel := range cancels {
+			cancel()
+		}
+		return ctx.Err()
+	}
+}
+
+// Wait blocks until the executor has been shut down and all its work, and
+// that of its pools, has finished or been abandoned. It is meant for process
+// teardown, after Shutdown has been called elsewhere.
+func (e *Executor) Wait() {
+	<-e.drained
+	e.mu.Lock()
+	pools := make([]*Executor, 0, len(e.pools))
+	for _, p := range e.pools {
+		pools = append(pools, p)
+	}
+	e.mu.Unlock()
+	for _, p := range pools {
+		p.Wait()
+	}
+}
+
+func (e *Executor) checkDrainedLocked() {
+	if e.closed && !e.isDrained && e.waiting == 0 && e.busy == 0 {
+		e.isDrained = true
+		close(e.drained)
+	}
+}
+
+func (e *Executor) worker() {
+	if e.lockThread {
+		// Never unlocked, so the thread ends with the worker.
+		runtime.LockOSThread()
+	}
+	idle := time.NewTimer(e.idleTimeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case <-e.queue:
+			e.mu.Lock()
+			t := e.pending.pop()
+			e.waiting--
+			e.busy++
+			abandon := e.aborting
+			if !abandon && t.cancel != nil {
+				if e.running == nil {
+					e.running = make(map[uint64]func())
+				}
+				e.running[t.seq] = t.cancel
+			}
+			grew := false
+			if e.autoscale != nil && !t.queued.IsZero() {
+				now := clockFor(e, options{}).Now()
+				grew = e.observeLocked(now.Sub(t.queued), now)
+			}
+			e.mu.Unlock()
+			if grew {
+				e.reportWorkers()
+			}
+
+			if !abandon {
+				t.run()
+			} else if t.reject != nil {
+				t.reject(ErrExecutorShutdown)
+			}
+
+			e.mu.Lock()
+			delete(e.running, t.seq)
+			e.busy--
+			e.checkDrainedLocked()
+			// Workers above an autoscaling limit that has shrunk retire.
+			retire := e.autoscale != nil && e.workers > e.autoscale.limit && e.workers > e.minIdle
+			if retire {
+				e.workers--
+			}
+			e.mu.Unlock()
+			if retire {
+				e.reportWorkers()
+				return
+			}
+
+		case <-idle.C:
+			e.mu.Lock()
+			if e.waiting == 0 && e.workers > e.minIdle {
+				e.workers--
+				e.mu.Unlock()
+				e.reportWorkers()
+				return
+			}
+			e.mu.Unlock()
+
+		case <-e.drained:
+			e.mu.Lock()
+			e.workers--
+			e.mu.Unlock()
+			e.reportWorkers()
+			return
+		}
+
+		if !idle.Stop() {
+			select {
+			case <-idle.C:
+			default:
+			}
+		}
+		idle.Reset(e.idleTimeout)
+	}
+}
@@ -0,0 +1,137 @@
+package futures_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutorBoundsConcurrency(t *testing.T) {
+	exec := futures.NewExecutor(2, 10)
+
+	var running, peak atomic.Int32
+	var fs []*futures.Future[int]
+	for i := 0; i < 6; i++ {
+		fs = append(fs, futures.Submit(exec, func() (int, error) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return i, nil
+		}))
+	}
+
+	for i, f := range fs {
+		v, err := f.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, i, v)
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestExecutorPrestartAndMinIdle(t *testing.T) {
+	exec := futures.NewExecutor(4, 0, futures.WithMinIdle(1), futures.WithIdleTimeout(5*time.Millisecond))
+	assert.Equal(t, 1, exec.Workers())
+
+	assert.Equal(t, 3, exec.Prestart(10))
+	assert.Equal(t, 4, exec.Workers())
+
+	// Idle workers retire, but never below the configured minimum.
+	assert.Eventually(t, func() bool { return exec.Workers() == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 1, exec.Workers())
+}
+
+func TestExecutorRunsChainedStagesWithoutDeadlock(t *testing.T) {
+	// A single worker would deadlock if a waiting stage occupied it.
+	exec := futures.NewExecutor(1, 0)
+
+	root := futures.Submit(exec, func() (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 1, nil
+	})
+	chain := futures.Then(futures.Then(root, func(n int) (int, error) {
+		return n + 1, nil
+	}), func(n int) (int, error) {
+		return n * 10, nil
+	})
+
+	v, err := chain.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 20, v)
+}
+
+func TestExecutorShutdownDrainsQueuedWork(t *testing.T) {
+	exec := futures.NewExecutor(1, 10)
+	var done atomic.Int32
+	var fs []*futures.Future[int]
+	for i := 0; i < 5; i++ {
+		fs = append(fs, futures.Submit(exec, func() (int, error) {
+			time.Sleep(time.Millisecond)
+			done.Add(1)
+			return i, nil
+		}))
+	}
+
+	assert.NoError(t, exec.Shutdown(context.Background()))
+	assert.Equal(t, int32(5), done.Load())
+
+	_, err := futures.Submit(exec, func() (int, error) { return 0, nil }).Result()
+	assert.ErrorIs(t, err, futures.ErrExecutorShutdown)
+	assert.ErrorIs(t, exec.Go(func() {}), futures.ErrExecutorShutdown)
+}
+
+func TestExecutorShutdownDeadlineAbandonsUnfinishedWork(t *testing.T) {
+	exec := futures.NewExecutor(1, 10)
+	release := make(chan struct{})
+	running := futures.Submit(exec, func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	queued := futures.Submit(exec, func() (int, error) { return 2, nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, exec.Shutdown(ctx), context.DeadlineExceeded)
+
+	_, err := running.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+
+	// The worker only lets go of the queue once the running task returns.
+	close(release)
+	_, err = queued.Result()
+	assert.ErrorIs(t, err, futures.ErrExecutorShutdown)
+	exec.Wait()
+}
+
+func TestExecutorWaitBlocksUntilDrained(t *testing.T) {
+	exec := futures.NewExecutor(2, 4)
+	pool := exec.Pool("io")
+	release := make(chan struct{})
+	f := futures.Submit(pool, func() (int, error) { <-release; return 1, nil })
+
+	waited := make(chan struct{})
+	go func() {
+		exec.Wait()
+		close(waited)
+	}()
+	go exec.Shutdown(context.Background())
+
+	select {
+	case <-waited:
+		t.Fatal("Wait returned with work still running")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	<-waited
+	assert.True(t, f.IsSuccess())
+}
@@ -0,0 +1,62 @@
+package futures
+
+import "context"
+
+// FlatMap chains an asynchronous step after f: fn returns a future of its
+// own, and the result settles like that inner future instead of producing a
+// nested *Future[*Future[U]]. fn only runs if f succeeds, and cancelling the
+// result also cancels the inner future.
+//
+// fn runs like a Then stage: on f's executor, through its middleware, and a
+// panic in fn rejects the result with a *PanicError.
+func FlatMap[T, U any](f *Future[T], fn func(T) *Future[U]) *Future[U] {
+	startParent(f)
+
+	next := newFuture[U](nil, f.executor, options{clock: f.clock})
+	next.started = true
+	next.prio, next.due = f.prio, f.due
+	linkNext(f, next)
+
+	f.whenSettled(func() {
+		result, err := f.outcome()
+		if err != nil {
+			var zero U
+			next.complete(zero, err)
+			return
+		}
+		scheduleStage(next, func() {
+			var inner *Future[U]
+			_, err := next.intercept(func(context.Context) (U, error) {
+				var zero U
+				inner = fn(result)
+				return zero, nil
+			})
+			if err == nil && inner == nil {
+				err = ErrNilFuture
+			}
+			if err != nil {
+				var zero U
+				next.complete(zero, next.stageError(err))
+				return
+			}
+			next.OnCancel(func() { inner.Cancel() })
+			inner.Start()
+			inner.whenSettled(func() {
+				next.complete(inner.outcome())
+			})
+		})
+	})
+	return next
+}
+
+// Flatten collapses a future of a future into a future settling like the
+// inner one. A failure of either layer rejects the result, and cancelling the
+// result cancels whichever layer is still pending.
+func Flatten[T any](f *Future[*Future[T]]) *Future[T] {
+	return FlatMap(f, func(inner *Future[T]) *Future[T] {
+		if inner == nil {
+			return Failed[T](ErrNilFuture)
+		}
+		return inner
+	})
+}
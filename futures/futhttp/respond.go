@@ -0,0 +1,83 @@
+// Package futhttp connects futures to net/http handlers.
+package futhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+// StatusClientClosedRequest is the non-standard status code (popularised by
+// nginx) used when the client went away before the future settled.
+const StatusClientClosedRequest = 499
+
+// EncodeFunc writes a successful result to the response.
+type EncodeFunc[T any] func(w http.ResponseWriter, v T) error
+
+// Respond waits for f within the lifetime of the request and writes its
+// outcome to w. A successful result is written with encode; failures are
+// mapped to a status code:
+//
+//   - the request deadline expiring yields 504 Gateway Timeout
+//   - the client disconnecting yields StatusClientClosedRequest
+//   - a cancelled future yields 503 Service Unavailable
+//   - any other error yields 500 Internal Server Error
+//
+// If the request context ends before f settles, f is cancelled so that its
+// result is not computed for nobody.
+func Respond[T any](w http.ResponseWriter, r *http.Request, f *futures.Future[T], encode EncodeFunc[T]) {
+	f.Start()
+
+	ctx := r.Context()
+	select {
+	case <-f.Done():
+	case <-ctx.Done():
+		if f.Cancel() {
+			writeError(w, ctx.Err())
+			return
+		}
+		// The future settled concurrently; respond with its outcome.
+	}
+
+	res, err := f.Result()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := encode(w, res); err != nil {
+		writeError(w, err)
+	}
+}
+
+// JSON is an EncodeFunc that writes v as a JSON document.
+func JSON[T any](w http.ResponseWriter, v T) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(v)
+}
+
+// StatusCode returns the HTTP status Respond uses for err.
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest
+	case errors.Is(err, futures.ErrCancelled):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	code := StatusCode(err)
+	text := http.StatusText(code)
+	if text == "" {
+		text = "Client Closed Request"
+	}
+	http.Error(w, text, code)
+}
@@ -0,0 +1,60 @@
+package futhttp_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/futhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRespondWritesResult(t *testing.T) {
+	f := futures.NewFuture(func() (map[string]int, error) {
+		return map[string]int{"answer": 42}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	futhttp.Respond(rec, req, f, futhttp.JSON[map[string]int])
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"answer":42}`, rec.Body.String())
+}
+
+func TestRespondMapsTaskError(t *testing.T) {
+	f := futures.NewFuture(func() (int, error) {
+		return 0, fmt.Errorf("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	futhttp.Respond(rec, req, f, futhttp.JSON[int])
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "boom")
+}
+
+func TestRespondCancelsOnDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.NewFuture(func() (int, error) {
+		<-release
+		return 1, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	futhttp.Respond(rec, req, f, futhttp.JSON[int])
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	_, err := f.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
@@ -0,0 +1,145 @@
+// Package futprom exports future lifecycle metrics to Prometheus.
+package futprom
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+// Collector is a futures.MetricsCollector backed by Prometheus metrics. It is
+// also a prometheus.Collector, so it can be registered directly:
+//
+//	c := futprom.NewCollector("myapp")
+//	prometheus.MustRegister(c)
+//	futures.SetMetricsCollector(c)
+//
+// Every metric carries a "pool" label naming the executor pool the future ran
+// on, empty outside of pools.
+type Collector struct {
+	*metrics
+	pool string
+}
+
+type metrics struct {
+	created      *prometheus.CounterVec
+	started      *prometheus.CounterVec
+	settled      *prometheus.CounterVec
+	queueWait    *prometheus.HistogramVec
+	execDuration *prometheus.HistogramVec
+	workers      *prometheus.GaugeVec
+}
+
+var (
+	_ futures.PoolMetricsCollector   = (*Collector)(nil)
+	_ futures.WorkerMetricsCollector = (*Collector)(nil)
+)
+
+// NewCollector creates a collector whose metrics are prefixed with namespace,
+// which may be empty.
+func NewCollector(namespace string) *Collector {
+	return &Collector{metrics: &metrics{
+		created: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "futures",
+			Name:      "created_total",
+			Help:      "Number of futures created.",
+		}, []string{"pool"}),
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "futures",
+			Name:      "started_total",
+			Help:      "Number of futures whose task started running.",
+		}, []string{"pool"}),
+		settled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "futures",
+			Name:      "settled_total",
+			Help:      "Number of settled futures by outcome.",
+		}, []string{"pool", "outcome"}),
+		queueWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "futures",
+			Name:      "queue_wait_seconds",
+			Help:      "Time tasks waited before running.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"pool"}),
+		execDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "futures",
+			Name:      "exec_duration_seconds",
+			Help:      "Time tasks spent running.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"pool"}),
+		workers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "futures",
+			Name:      "workers",
+			Help:      "Number of live executor workers.",
+		}, []string{"pool"}),
+	}}
+}
+
+// ForPool implements futures.PoolMetricsCollector. The returned collector
+// shares c's metrics, labelled with the pool name.
+func (c *Collector) ForPool(name string) futures.MetricsCollector {
+	return &Collector{metrics: c.metrics, pool: name}
+}
+
+// FutureCreated implements futures.MetricsCollector.
+func (c *Collector) FutureCreated() {
+	c.created.WithLabelValues(c.pool).Inc()
+}
+
+// FutureStarted implements futures.MetricsCollector.
+func (c *Collector) FutureStarted(queueWait time.Duration) {
+	c.started.WithLabelValues(c.pool).Inc()
+	c.queueWait.WithLabelValues(c.pool).Observe(queueWait.Seconds())
+}
+
+// FutureSettled implements futures.MetricsCollector.
+func (c *Collector) FutureSettled(state futures.State, execDuration time.Duration) {
+	c.settled.WithLabelValues(c.pool, outcome(state)).Inc()
+	if execDuration > 0 {
+		c.execDuration.WithLabelValues(c.pool).Observe(execDuration.Seconds())
+	}
+}
+
+// WorkersChanged implements futures.WorkerMetricsCollector. Executors sharing
+// a collector outside of pools share the gauge, so give each its own
+// collector to tell them apart.
+func (c *Collector) WorkersChanged(workers int) {
+	c.workers.WithLabelValues(c.pool).Set(float64(workers))
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.created.Describe(ch)
+	c.started.Describe(ch)
+	c.settled.Describe(ch)
+	c.queueWait.Describe(ch)
+	c.execDuration.Describe(ch)
+	c.workers.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.created.Collect(ch)
+	c.started.Collect(ch)
+	c.settled.Collect(ch)
+	c.queueWait.Collect(ch)
+	c.execDuration.Collect(ch)
+	c.workers.Collect(ch)
+}
+
+func outcome(s futures.State) string {
+	switch s {
+	case futures.Fulfilled:
+		return "success"
+	case futures.Cancelled:
+		return "cancelled"
+	default:
+		return "failure"
+	}
+}
@@ -0,0 +1,74 @@
+package futprom_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/futprom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorCountsExecutorFutures(t *testing.T) {
+	c := futprom.NewCollector("test")
+	reg := prometheus.NewPedanticRegistry()
+	assert.NoError(t, reg.Register(c))
+
+	e := futures.NewExecutor(2, 4, futures.WithMetrics(c))
+	ok := futures.Submit(e, func() (int, error) { return 1, nil })
+	failed := futures.Submit(e, func() (int, error) { return 0, errors.New("boom") })
+	_, _ = ok.Result()
+	_, _ = failed.Result()
+
+	expected := `
+# HELP test_futures_created_total Number of futures created.
+# TYPE test_futures_created_total counter
+test_futures_created_total{pool=""} 2
+# HELP test_futures_settled_total Number of settled futures by outcome.
+# TYPE test_futures_settled_total counter
+test_futures_settled_total{outcome="failure",pool=""} 1
+test_futures_settled_total{outcome="success",pool=""} 1
+# HELP test_futures_started_total Number of futures whose task started running.
+# TYPE test_futures_started_total counter
+test_futures_started_total{pool=""} 2
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"test_futures_created_total", "test_futures_settled_total", "test_futures_started_total"))
+}
+
+func TestCollectorLabelsPools(t *testing.T) {
+	c := futprom.NewCollector("test")
+	reg := prometheus.NewPedanticRegistry()
+	assert.NoError(t, reg.Register(c))
+
+	e := futures.NewExecutor(2, 4, futures.WithMetrics(c))
+	_, _ = futures.Submit(e, func() (int, error) { return 1, nil }).Result()
+	_, _ = futures.Submit(e.Pool("db"), func() (int, error) { return 1, nil }).Result()
+
+	expected := `
+# HELP test_futures_created_total Number of futures created.
+# TYPE test_futures_created_total counter
+test_futures_created_total{pool=""} 1
+test_futures_created_total{pool="db"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "test_futures_created_total"))
+}
+
+func TestCollectorTracksWorkers(t *testing.T) {
+	c := futprom.NewCollector("test")
+	reg := prometheus.NewPedanticRegistry()
+	assert.NoError(t, reg.Register(c))
+
+	e := futures.NewExecutor(4, 4, futures.WithMetrics(c), futures.WithMinIdle(2))
+	e.Prestart(1)
+
+	expected := `
+# HELP test_futures_workers Number of live executor workers.
+# TYPE test_futures_workers gauge
+test_futures_workers{pool=""} 3
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "test_futures_workers"))
+}
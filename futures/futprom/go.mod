@@ -0,0 +1,29 @@
+module github.com/sauravbiswasiupr/go-futures/futures/futprom
+
+go 1.24.1
+
+require (
+	github.com/prometheus/client_golang v1.22.0
+	github.com/sauravbiswasiupr/go-futures v0.0.0
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/kylelemons/godebug v1.1.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_model v0.6.1 // indirect
+	github.com/prometheus/common v0.62.0 // indirect
+	github.com/prometheus/procfs v0.15.1 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	google.golang.org/protobuf v1.36.5 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The parent module is not tagged yet: v0.0.0 resolves through this replace
+// directive. See "Installation" in the README for the release order.
+replace github.com/sauravbiswasiupr/go-futures => ../..
@@ -1,140 +1,932 @@
 package futures
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Future represents the result of an asynchronous computation.
 type Future[T any] struct {
-	task      func() (T, error)
+	task      func(ctx context.Context) (T, error)
+	ctx       context.Context // Passed to the task; nil means context.Background
 	mutex     sync.Mutex
 	result    T
 	err       error
-	state     State
-	done      chan struct{}
-	next      interface{} // Link to the next future in the chain (any type)
+	state     atomicState   // Written under mutex, read without it
+	done      chan struct{} // Made on first use and closed once settled, see doneChan
+	next      interface{}   // Link to the next future in the chain (any type)
 	onSuccess []func(T)
 	onFailure []func(error)
+	onCancel  []func()
+	onDone    []func(T, error)
+	onSettle  []func() // Internal continuations, run once done is closed
 	started   bool
+	executor  *Executor        // Runs the task; nil means a dedicated goroutine
+	timeline  *Timeline        // Opt-in recorder shared along the chain
+	stage     int              // Position in the chain, 0 for the root
+	execStart time.Time        // When the task's own work began
+	cancel    func()           // Releases the task's context once the future settles
+	queuedAt  time.Time        // When the task became ready to run
+	metrics   MetricsCollector // Receives lifecycle events; nil when metrics are off
+	lazy      bool             // Started only on behalf of a consumer, see StartLazy
+	onDemand  func()           // Starts whatever the future waits on, for lazy chains
+	mws       []Middleware     // Wraps the task, chained stages and callbacks
+	timeout   time.Duration    // Stage deadline set with WithTimeout, zero if none
+	timer     Timer            // Enforces timeout once the stage runs
+	clock     Clock            // Source of timestamps and timers
+	name      string           // Optional stage name, see ThenNamed
+	pipeline  string           // Name of the chain's root, see NewFutureNamed
+	onState   []func(old, new State)
+	createdAt time.Time
+	settledAt time.Time
+	parent    ChainNode   // The future this stage was chained from, if any
+	children  []ChainNode // Stages chained from this future
+
+	// admit, if set, blocks until the future may run, reporting false if
+	// the channel returned by cancel was closed first. See Throttle. cancel
+	// is only called if admission has to wait.
+	admit func(cancel func() <-chan struct{}) bool
+
+	// consumers counts the derived futures and waiters still interested in
+	// the outcome; upstream releases this future's hold on its own inputs.
+	// See dependOn.
+	consumers atomic.Int32
+	upstream  []releaser
+
+	prio int       // Executor queue priority, see SubmitPriority
+	due  time.Time // Executor queue deadline, see SubmitDeadline
+
+	onProgress []func(Progress)
+	progress   Progress // Latest update, valid once reported is set
+	reported   bool
+
+	expiry Timer // Enforces the deadline set with WithDeadline
+
+	queue completionQueue // Runs user callbacks in order, see schedule
+
+	slow Timer // Reports the task to its executor's OnSlowTask handler
+
+	rootCtx context.Context // Given to NewFutureCtx for the chain's root, see ThenCtx
+
+	logger *slog.Logger // Receives lifecycle events; nil when logging is off
+
+	// Inline storage for the common case of a single continuation and a
+	// single input, saving an allocation for each stage of a chain.
+	settleBuf [1]func()
+	upBuf     [1]releaser
+	signalled atomic.Bool // done has been closed, or would have been; the outcome is final
+	finished  atomic.Bool // complete is done with the future, so Release may recycle it
+}
+
+// NewFuture creates a new Future instance. By default it follows
+// StartOnDemand; pass WithStartPolicy to choose another policy.
+func NewFuture[T any](task func() (T, error), opts ...Option) *Future[T] {
+	return newFuture(task, nil, buildOptions(opts))
 }
 
-// NewFuture creates a new Future instance.
-func NewFuture[T any](task func() (T, error)) *Future[T] {
-	return &Future[T]{
-		task:      task,
-		state:     Pending,
-		done:      make(chan struct{}),
-		onSuccess: []func(T){},
-		onFailure: []func(error){},
-		started:   false,
+// newFuture creates a future whose task runs on exec, or on its own goroutine
+// if exec is nil.
+func newFuture[T any](task func() (T, error), exec *Executor, o options) *Future[T] {
+	f := allocFuture[T]()
+	f.executor = exec
+	f.metrics = collectorFor(exec)
+	f.lazy = o.start == StartLazy
+	f.mws = middlewareFor(exec)
+	f.clock = clockFor(exec, o)
+	f.logger = loggerFor(exec)
+	f.createdAt = f.clock.Now()
+	if exec != nil && exec.limiter != nil {
+		f.admit = exec.limiter.wait
+	}
+	if task != nil {
+		f.task = func(context.Context) (T, error) { return task() }
 	}
+	if f.metrics != nil {
+		f.metrics.FutureCreated()
+	}
+	f.log(slog.LevelDebug, "future created")
+	attachToken(o.token, f)
+	if o.start == StartEager {
+		f.Start()
+	}
+	return f
+}
+
+// newSelfFuture is newFuture for a task that refers to the future it runs
+// for, such as one reporting progress.
+func newSelfFuture[T any](o options, task func(f *Future[T]) (T, error)) *Future[T] {
+	eager := o.start == StartEager
+	if eager {
+		// Start only once the task can refer to f.
+		o.start = StartOnDemand
+	}
+	var f *Future[T]
+	f = newFuture(func() (T, error) { return task(f) }, nil, o)
+	if eager {
+		f.Start()
+	}
+	return f
+}
+
+// NewFutureCtx creates a new Future whose task receives a context derived
+// from ctx. If ctx times out before the task finishes, the future rejects
+// with ctx.Err() immediately; if ctx is cancelled, the future is cancelled,
+// running its OnCancel callbacks, with an error matching both ErrCancelled
+// and context.Canceled. The task's context is also cancelled once the future
+// settles, including through Cancel, so a task should watch it to stop work
+// whose result nobody will see.
+func NewFutureCtx[T any](ctx context.Context, task func(ctx context.Context) (T, error)) *Future[T] {
+	taskCtx, cancelTask := context.WithCancel(ctx)
+
+	// A task that returns as soon as ctx is cancelled may beat the AfterFunc
+	// below; its error is reported as a cancellation all the same.
+	f := NewFuture[T](nil)
+	f.task = func(c context.Context) (T, error) {
+		res, err := task(c)
+		if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+			err = cancelledBy(ctx)
+		}
+		return res, err
+	}
+	f.ctx = taskCtx
+	f.rootCtx = ctx
+
+	stop := context.AfterFunc(ctx, func() {
+		var zero T
+		f.complete(zero, cancelledBy(ctx))
+	})
+	f.cancel = func() {
+		stop()
+		cancelTask()
+	}
+	return f
+}
+
+// ThenCtx is Then for a step taking a context, so that its I/O can be
+// aborted once the chain is abandoned. The context is derived from the one
+// the chain's root was created with by NewFutureCtx, or from
+// context.Background for other chains, carrying its cancellation and
+// deadline through every stage. It is also cancelled once the step settles,
+// including through Cancel. If the root's context ends before the step has
+// settled, the step settles right away as a NewFutureCtx task would:
+// cancelled if the context was cancelled, rejected with its error otherwise.
+func ThenCtx[T, U any](f *Future[T], fn func(ctx context.Context, v T) (U, error)) *Future[U] {
+	f.mutex.Lock()
+	root := f.rootCtx
+	f.mutex.Unlock()
+	if root == nil {
+		root = context.Background()
+	}
+	ctx, cancel := context.WithCancel(root)
+
+	next := chain(f, func(result T, err error) (U, error) {
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		res, err := fn(ctx, result)
+		if errors.Is(err, context.Canceled) && root.Err() != nil {
+			err = cancelledBy(root)
+		}
+		return res, err
+	})
+	stop := context.AfterFunc(root, func() {
+		var zero U
+		next.complete(zero, cancelledBy(root))
+	})
+	next.whenSettled(func() {
+		stop()
+		cancel()
+	})
+	return next
+}
+
+// cancelledBy returns the error settling a future whose context ended: a
+// cancellation matching ctx.Err() if ctx was cancelled, or ctx.Err() itself.
+func cancelledBy(ctx context.Context) error {
+	err := ctx.Err()
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%w: %w", ErrCancelled, err)
+	}
+	return err
 }
 
 // Then chains a new computation step to the current Future.
+// Results are passed as any; use the package-level Then to keep static types.
 func (f *Future[T]) Then(nextTask func(T) (any, error)) *Future[any] {
-	// Make sure the current future is started
-	if !f.started {
-		go f.Start()
-	}
+	return Then(f, nextTask)
+}
 
-	nextFuture := NewFuture(func() (any, error) {
-		// Wait for the parent future to complete
-		result, err := f.Result()
+// Then chains fn after f and returns a future for its result, preserving
+// static types end to end:
+//
+//	n := futures.NewFuture(fetchCount)                  // *Future[int]
+//	s := futures.Then(n, func(n int) (string, error) {  // *Future[string]
+//		return strconv.Itoa(n), nil
+//	})
+//
+// f is started if it has not been yet, unless it is lazy (see StartLazy), and
+// fn only runs if f succeeds; otherwise the returned future fails with f's
+// error.
+func Then[T, U any](f *Future[T], fn func(T) (U, error)) *Future[U] {
+	return chain(f, func(result T, err error) (U, error) {
 		if err != nil {
-			return nil, err
+			var zero U
+			return zero, err
 		}
 
 		// Execute the next task with the result from the parent
-		return nextTask(result)
+		return fn(result)
+	})
+}
+
+// chain returns a future computed by next from the outcome of f, once f has
+// settled. It is the building block of Then and the other continuations.
+func chain[T, U any](f *Future[T], next func(T, error) (U, error)) *Future[U] {
+	return chainNamed(f, "", next)
+}
+
+// chainNamed is chain for a stage with a user-supplied name.
+//
+// The stage is registered as a continuation of f rather than given a task of
+// its own: nothing waits for f, and a goroutine (or an executor slot) is only
+// taken once f has settled and the stage actually runs.
+func chainNamed[T, U any](f *Future[T], name string, next func(T, error) (U, error)) *Future[U] {
+	startParent(f)
+
+	exec := f.executor
+	nextFuture := newFuture[U](nil, exec, options{clock: f.clock})
+	nextFuture.started = true
+	nextFuture.name = name
+	nextFuture.prio, nextFuture.due = f.prio, f.due
+	linkNext(f, nextFuture)
+
+	f.whenSettled(func() {
+		result, err := f.outcome()
+		scheduleStage(nextFuture, func() {
+			nextFuture.complete(runStage(nextFuture, next, result, err))
+		})
+	})
+	return nextFuture
+}
+
+// scheduleStage runs work for the stage f once its parent has settled, on
+// f's executor or on a goroutine of its own. work is skipped if f settles
+// first, and f is marked running before it starts.
+func scheduleStage[U any](f *Future[U], work func()) {
+	f.markQueued()
+	run := func() {
+		if f.State().settled() || !f.admitted() {
+			return
+		}
+		f.beginExec()
+		work()
+	}
+	exec := f.executor
+	if exec == nil {
+		go run()
+		return
+	}
+
+	// Never block on the queue: the parent may be settling on one of its
+	// workers.
+	fail := func(err error) {
+		var zero U
+		f.complete(zero, err)
+	}
+	cancel := func() { f.Cancel() }
+	t := execTask{run: run, reject: fail, cancel: cancel, priority: f.prio, deadline: f.due}
+	if err := exec.submit(t, false); err != nil {
+		fail(err)
+	}
+}
+
+// runStage computes the stage f from its parent's outcome. Errors raised by
+// the stage itself, rather than passed on from upstream, are wrapped in a
+// StageError naming it.
+func runStage[T, U any](f *Future[U], next func(T, error) (U, error), result T, parentErr error) (U, error) {
+	res, err := f.intercept(func(context.Context) (U, error) {
+		return next(result, parentErr)
 	})
+	if err != nil && (parentErr == nil || !errors.Is(err, parentErr)) {
+		err = f.stageError(err)
+	}
+	return res, err
+}
+
+// startParent starts f ahead of a continuation being chained onto it. Lazy
+// futures are left alone: the continuation starts them once it is consumed.
+func startParent[T any](f *Future[T]) {
+	if f.lazy {
+		return
+	}
+	f.mutex.Lock()
+	started := f.started
+	f.mutex.Unlock()
+	if !started {
+		go f.Start()
+	}
+}
+
+// linkNext records next as the continuation of f.
+func linkNext[T, U any](f *Future[T], next *Future[U]) {
+	// A continuation of a lazy future is lazy too, and starting it starts f.
+	if f.lazy {
+		next.lazy = true
+		next.onDemand = f.Start
+	}
 
 	// Link futures for debugging/tracing
 	f.mutex.Lock()
-	f.next = nextFuture
+	f.next = next
+	f.children = append(f.children, next)
+	next.timeline = f.timeline
+	next.stage = f.stage + 1
+	next.pipeline = f.pipeline
+	next.rootCtx = f.rootCtx
+	next.parent = f
 	f.mutex.Unlock()
+	dependOn(next, f)
+}
 
-	return nextFuture
+// Recover chains a step that runs only if f fails, turning the error into a
+// value (for example a cached copy) or into another error. If f succeeds its
+// result is passed through unchanged.
+func (f *Future[T]) Recover(handler func(error) (T, error)) *Future[T] {
+	return chain(f, func(result T, err error) (T, error) {
+		if err == nil {
+			return result, nil
+		}
+		return handler(err)
+	})
+}
+
+// MapErr chains a step that translates the error of a failed f, for example
+// from a driver error into a domain error at a package boundary. Unlike
+// Recover it cannot turn a failure into a success: if fn returns nil, the
+// original error is kept. Results and cancellation pass through unchanged.
+func (f *Future[T]) MapErr(fn func(error) error) *Future[T] {
+	return chain(f, func(result T, err error) (T, error) {
+		if err == nil || errors.Is(err, ErrCancelled) {
+			return result, err
+		}
+		if mapped := fn(err); mapped != nil {
+			err = mapped
+		}
+		var zero T
+		return zero, err
+	})
+}
+
+// FallbackTo returns a future resolving like f if it succeeds, and like other
+// otherwise. other is only started once f has failed, so a secondary source is
+// not queried unless the primary one is unavailable.
+func (f *Future[T]) FallbackTo(other *Future[T]) *Future[T] {
+	return chain(f, func(result T, err error) (T, error) {
+		if err == nil {
+			return result, nil
+		}
+		return other.Result()
+	})
 }
 
-// Start starts the future by executing the task asynchronously.
+// Start starts the future by executing the task asynchronously. For a
+// future chained from a lazy one, it starts the chain above it as well.
 func (f *Future[T]) Start() {
+	if f.onDemand != nil {
+		f.onDemand()
+	}
+
+	// Waiters on a running future all call Start; spare them the mutex.
+	if f.state.Load() != Pending {
+		return
+	}
 	f.mutex.Lock()
-	if f.state != Pending || f.started {
+	if f.state.Load() != Pending || f.started {
 		f.mutex.Unlock()
 		return
 	}
 	f.started = true
-	f.state = Running
+	f.state.Store(Running)
+	f.queuedAt = f.clock.Now()
+	listeners := f.stateListenersLocked()
 	f.mutex.Unlock()
+	notifyState(listeners, Pending, Running)
 
-	go func() {
-		res, err := f.task()
-
-		f.mutex.Lock()
-		if f.state != Running { // Check if already completed somehow
-			f.mutex.Unlock()
+	run := func() {
+		if !f.admitted() {
 			return
 		}
+		f.beginExec()
+		res, err := f.intercept(f.task)
+		f.complete(res, err)
+	}
+	if f.executor != nil {
+		var zero T
+		reject := func(err error) { f.complete(zero, err) }
+		cancel := func() { f.Cancel() }
+		t := execTask{run: run, reject: reject, cancel: cancel, priority: f.prio, deadline: f.due}
+		if err := f.executor.submit(t, true); err != nil {
+			reject(err)
+		}
+	} else {
+		go run()
+	}
+}
 
-		if err != nil {
-			f.err = err
-			f.state = Rejected
-			// Execute failure callbacks
-			callbacks := make([]func(error), len(f.onFailure))
-			copy(callbacks, f.onFailure)
-			f.mutex.Unlock()
-
-			// Execute callbacks outside the lock
-			for _, cb := range callbacks {
-				cb(err)
+// complete settles the future with the given outcome, runs the matching
+// callbacks and releases every waiter. It reports false if the future had
+// already settled, in which case the outcome is discarded.
+//
+// The check and the writes of the outcome and terminal state share one hold
+// of f.mutex, so when the task, Cancel and timeouts race exactly one caller
+// wins; only the winner goes on to run callbacks and close done, which is
+// therefore closed once.
+func (f *Future[T]) complete(res T, err error) bool {
+	f.mutex.Lock()
+	if f.state.Load().settled() {
+		f.mutex.Unlock()
+		return false
+	}
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+	if f.expiry != nil {
+		f.expiry.Stop()
+	}
+	if f.slow != nil {
+		f.slow.Stop()
+	}
+	end := f.clock.Now()
+	f.settledAt = end
+	oldState := f.state.Load()
+	stateListeners := f.onState
+	f.onState = nil
+	if f.timeline != nil && !f.execStart.IsZero() {
+		f.timeline.add(Span{Name: f.label(), Stage: f.stage, Start: f.execStart, End: end, Err: err})
+	}
+	var execDuration time.Duration
+	if !f.execStart.IsZero() {
+		execDuration = end.Sub(f.execStart)
+	}
+	upstream := f.upstream
+	f.upstream = nil
+
+	newState := Fulfilled
+	if err != nil {
+		// A failed future has no result, whatever the task returned
+		var zero T
+		res = zero
+		f.err = err
+		newState = Rejected
+		if errors.Is(err, ErrCancelled) {
+			newState = Cancelled
+		}
+	} else {
+		f.result = res
+	}
+	drain := f.queueCallbacks(newState, res, err)
+	// Publish the state last: readers that see it settled without the lock
+	// rely on the result fields being set, and callbacks registered from
+	// then on are queued behind those taken above.
+	f.state.Store(newState)
+	f.mutex.Unlock()
+	notifyState(stateListeners, oldState, newState)
+
+	if f.metrics != nil {
+		f.metrics.FutureSettled(newState, execDuration)
+	}
+	if f.logger != nil {
+		f.logSettled(newState, err, execDuration)
+	}
+	if drain {
+		f.runCallbacks()
+	}
+
+	if f.cancel != nil {
+		f.cancel()
+	}
+	abandon := abandons(err)
+	for _, in := range upstream {
+		in.release(abandon)
+	}
+
+	// Signal completion after callbacks
+	f.mutex.Lock()
+	f.signalled.Store(true)
+	if f.done != nil {
+		close(f.done)
+	}
+	continuations := f.onSettle
+	f.onSettle = nil
+	f.mutex.Unlock()
+	for _, cb := range continuations {
+		cb()
+	}
+
+	// Drop the references left in the inline buffers.
+	f.mutex.Lock()
+	f.settleBuf, f.upBuf = [1]func(){}, [1]releaser{}
+	f.mutex.Unlock()
+	// Last of all: from here on complete no longer touches f.
+	f.finished.Store(true)
+	return true
+}
+
+// queueCallbacks takes the callbacks registered so far, releasing them from
+// the future as it settles, and queues those matching newState: OnCancel
+// callbacks, then OnFailure or OnSuccess ones, then OnComplete ones.
+// Unsubscribed callbacks are left as nil and skipped. It reports whether the
+// caller must drain the queue. f.mutex must be held.
+func (f *Future[T]) queueCallbacks(newState State, res T, err error) bool {
+	drain := false
+	queue := func(fn func()) {
+		if f.queue.push(fn) {
+			drain = true
+		}
+	}
+	if newState == Cancelled {
+		for _, cb := range f.onCancel {
+			if cb != nil {
+				queue(cb)
 			}
-		} else {
-			f.result = res
-			f.state = Fulfilled
-			// Execute success callbacks
-			callbacks := make([]func(T), len(f.onSuccess))
-			copy(callbacks, f.onSuccess)
-			f.mutex.Unlock()
-
-			// Execute callbacks outside the lock
-			for _, cb := range callbacks {
-				cb(res)
+		}
+	}
+	if err != nil {
+		for _, cb := range f.onFailure {
+			if cb != nil {
+				queue(func() { cb(err) })
+			}
+		}
+	} else {
+		for _, cb := range f.onSuccess {
+			if cb != nil {
+				queue(func() { cb(res) })
 			}
 		}
+	}
+	for _, cb := range f.onDone {
+		if cb != nil {
+			queue(func() { cb(res, err) })
+		}
+	}
+	f.onDone, f.onSuccess, f.onFailure, f.onCancel = nil, nil, nil, nil
+	f.onProgress = nil
+	return drain
+}
 
-		// Signal completion after callbacks
-		close(f.done)
+// intercept runs call, the task or a chained stage, through the future's
+// middleware. A panic is turned into a PanicError.
+func (f *Future[T]) intercept(call func(context.Context) (T, error)) (res T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			res, err = zero, &PanicError{Value: r, Stack: debug.Stack()}
+		}
 	}()
+
+	ctx := f.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	f.runLabelled(ctx, func(ctx context.Context) {
+		res, err = intercept(f.mws, ctx, call)
+	})
+	return res, err
+}
+
+// callback runs a user callback through the future's middleware.
+func (f *Future[T]) callback(fn func()) {
+	if len(f.mws) == 0 {
+		fn()
+		return
+	}
+	_, _ = intercept(f.mws, context.Background(), func(context.Context) (any, error) {
+		fn()
+		return nil, nil
+	})
+}
+
+// whenSettled runs cb once the future has settled and its waiters have been
+// released, or right away if it already has.
+func (f *Future[T]) whenSettled(cb func()) {
+	f.mutex.Lock()
+	if f.state.Load().settled() && f.onSettle == nil {
+		f.mutex.Unlock()
+		cb()
+		return
+	}
+	if f.onSettle == nil {
+		f.onSettle = f.settleBuf[:0]
+	}
+	f.onSettle = append(f.onSettle, cb)
+	f.mutex.Unlock()
+}
+
+// closedChan stands in for the done channel of futures that settled before
+// anyone asked for it.
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// doneChan returns the channel closed once the future has settled and its
+// callbacks have run. Most futures are never waited on through a channel, so
+// it is only made on first use.
+func (f *Future[T]) doneChan() chan struct{} {
+	if f.signalled.Load() {
+		return closedChan
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.done == nil {
+		if f.signalled.Load() {
+			return closedChan
+		}
+		f.done = make(chan struct{})
+	}
+	return f.done
+}
+
+// outcome returns the settled result without waiting.
+func (f *Future[T]) outcome() (T, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.result, f.err
+}
+
+// admitted waits until the future may run, reporting false if it settled
+// in the meantime.
+func (f *Future[T]) admitted() bool {
+	f.mutex.Lock()
+	admit := f.admit
+	f.mutex.Unlock()
+	return admit == nil || admit(f.settled)
+}
+
+// settled is doneChan as a receive-only channel, for waits that only need it
+// once they block.
+func (f *Future[T]) settled() <-chan struct{} {
+	return f.doneChan()
+}
+
+// beginExec marks the moment the future starts doing its own work, as
+// opposed to waiting for its parent.
+func (f *Future[T]) beginExec() {
+	f.mutex.Lock()
+	var listeners []func(old, new State)
+	if f.state.Load() == Pending {
+		f.state.Store(Running)
+		listeners = f.stateListenersLocked()
+	}
+	f.execStart = f.clock.Now()
+	var queueWait time.Duration
+	if !f.queuedAt.IsZero() {
+		queueWait = f.execStart.Sub(f.queuedAt)
+	}
+	if f.timeout > 0 {
+		f.armTimeoutLocked()
+	}
+	if f.executor != nil && f.executor.onSlow != nil {
+		f.armSlowLocked(queueWait)
+	}
+	f.mutex.Unlock()
+
+	notifyState(listeners, Pending, Running)
+	if f.metrics != nil {
+		f.metrics.FutureStarted(queueWait)
+	}
+	f.log(slog.LevelDebug, "future started", slog.Duration("queue_wait", queueWait))
+}
+
+// stateListenersLocked returns a copy of the OnStateChange listeners.
+func (f *Future[T]) stateListenersLocked() []func(old, new State) {
+	if len(f.onState) == 0 {
+		return nil
+	}
+	return slices.Clone(f.onState)
+}
+
+func notifyState(listeners []func(old, new State), old, new State) {
+	for _, cb := range listeners {
+		if cb != nil {
+			cb(old, new)
+		}
+	}
+}
+
+// markQueued records that the future's task is ready to run from now on.
+func (f *Future[T]) markQueued() {
+	f.mutex.Lock()
+	f.queuedAt = f.clock.Now()
+	f.mutex.Unlock()
+}
+
+// label names the future in recordings.
+func (f *Future[T]) label() string {
+	if f.name != "" {
+		return f.name
+	}
+	return fmt.Sprintf("stage %d", f.stage)
+}
+
+// stageError attributes err to this stage of the chain.
+func (f *Future[T]) stageError(err error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return &StageError{Stage: f.stage, Name: f.name, Err: err}
+}
+
+// Cancel moves a future that has not settled yet to the Cancelled state with
+// ErrCancelled as its error and releases its waiters. OnCancel callbacks run,
+// followed by OnFailure callbacks. A running task created with NewFutureCtx
+// sees its context cancelled; other tasks are not interrupted, and their
+// eventual result is discarded. Cancel reports whether the future was
+// cancelled, i.e. false if it had already settled.
+//
+// Cancellation flows down a chain: futures chained from a cancelled future
+// fail with its ErrCancelled and so become Cancelled as well. It also flows
+// up, to inputs nothing else needs any more: a future is cancelled once every
+// future derived from it (with Then, All, Race and the like) has been
+// cancelled or has timed out, unless a caller is blocked in its Result.
+func (f *Future[T]) Cancel() bool {
+	var zero T
+	return f.complete(zero, ErrCancelled)
 }
 
 // Result returns the result of the future computation.
 // It starts the future if it hasn't been started yet.
 func (f *Future[T]) Result() (T, error) {
+	// Once done is closed the outcome is never written again, so waiters
+	// read it without taking the mutex.
+	if f.signalled.Load() {
+		return f.result, f.err
+	}
+
 	// Auto-start if not already started
-	if !f.started {
-		f.Start()
+	f.Start()
+
+	// A blocked caller counts as a consumer, so the future is not cancelled
+	// from downstream while it is still being waited for.
+	if !f.state.Load().settled() {
+		f.retain()
+		defer f.release(false)
 	}
+	<-f.doneChan() // Wait for completion
+	return f.result, f.err
+}
+
+// State returns the current state of the future. It never blocks.
+func (f *Future[T]) State() State {
+	return f.state.Load()
+}
+
+// IsDone reports whether the future has settled, whichever way. It never
+// blocks.
+func (f *Future[T]) IsDone() bool {
+	return f.state.Load().settled()
+}
+
+// IsSuccess reports whether the future was fulfilled. It never blocks.
+func (f *Future[T]) IsSuccess() bool {
+	return f.state.Load() == Fulfilled
+}
+
+// IsFailed reports whether the future settled with an error. As with
+// OnFailure, a cancelled future counts as failed. It never blocks.
+func (f *Future[T]) IsFailed() bool {
+	s := f.state.Load()
+	return s == Rejected || s == Cancelled
+}
+
+// IsCancelled reports whether the future was cancelled. It never blocks.
+func (f *Future[T]) IsCancelled() bool {
+	return f.state.Load() == Cancelled
+}
 
-	<-f.done // Wait for completion
+// CreatedAt returns when the future was created.
+func (f *Future[T]) CreatedAt() time.Time {
+	return f.createdAt
+}
 
+// StartedAt returns when the future's own work began: its task, or for a
+// chained stage, its step once the parent had settled. It is zero if the
+// work has not begun, and stays zero for promises.
+func (f *Future[T]) StartedAt() time.Time {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
-	return f.result, f.err
+	return f.execStart
 }
 
-// State returns the current state of the future.
-func (f *Future[T]) State() State {
+// Queued reports whether the future has been started but its own work has
+// not begun yet: it is waiting for an executor worker, a rate limiter or,
+// for a chained stage, its turn to run after the parent settled.
+func (f *Future[T]) Queued() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return !f.queuedAt.IsZero() && f.execStart.IsZero() && !f.state.Load().settled()
+}
+
+// QueueWait returns how long the future waited between becoming ready to run
+// and its work beginning, or so far if it is still Queued.
+func (f *Future[T]) QueueWait() time.Duration {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.queueWaitLocked()
+}
+
+func (f *Future[T]) queueWaitLocked() time.Duration {
+	switch {
+	case f.queuedAt.IsZero():
+		return 0
+	case !f.execStart.IsZero():
+		return f.execStart.Sub(f.queuedAt)
+	case f.state.Load().settled():
+		return f.settledAt.Sub(f.queuedAt)
+	default:
+		return f.clock.Now().Sub(f.queuedAt)
+	}
+}
+
+// SettledAt returns when the future settled, or zero if it has not yet.
+func (f *Future[T]) SettledAt() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.settledAt
+}
+
+// Duration returns how long the future's own work took, from StartedAt to
+// SettledAt, or so far if it is still running. It is zero if the work never
+// began.
+func (f *Future[T]) Duration() time.Duration {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
-	return f.state
+	return f.durationLocked()
+}
+
+func (f *Future[T]) durationLocked() time.Duration {
+	switch {
+	case f.execStart.IsZero():
+		return 0
+	case f.settledAt.IsZero():
+		return f.clock.Now().Sub(f.execStart)
+	default:
+		return f.settledAt.Sub(f.execStart)
+	}
+}
+
+// QueueLatency is QueueWait, under the name used for executor metrics: the
+// time spent waiting for a worker, as opposed to ExecLatency, the time spent
+// working. Comparing the two tells a saturated executor from slow tasks.
+func (f *Future[T]) QueueLatency() time.Duration {
+	return f.QueueWait()
+}
+
+// ExecLatency is Duration, under the name used for executor metrics; see
+// QueueLatency.
+func (f *Future[T]) ExecLatency() time.Duration {
+	return f.Duration()
+}
+
+// Done returns a channel that is closed once the future has settled, for use
+// in select statements alongside contexts and timers. It does not start the
+// future.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.doneChan()
+}
+
+// TryResult returns the outcome of the future without blocking. The last
+// return value reports whether the future has settled; if it is false the
+// result and error are zero.
+func (f *Future[T]) TryResult() (T, error, bool) {
+	if !f.state.Load().settled() {
+		var zero T
+		return zero, nil, false
+	}
+	// The outcome is never written again once the state is published.
+	return f.result, f.err, true
 }
 
 // GetDone returns the done channel (used internally for chaining)
+//
+// Deprecated: Use Done, which returns a receive-only channel that callers
+// cannot close by accident.
 func (f *Future[T]) GetDone() chan struct{} {
-	return f.done
+	return f.doneChan()
 }
 
 // GetNext returns the next future in the chain (used internally for chaining)
+//
+// Deprecated: Use Children, which lists every stage chained from the future
+// rather than only the latest, and Parent to walk the other way.
 func (f *Future[T]) GetNext() interface{} {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
@@ -1,7 +1,10 @@
 package futures_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -71,3 +74,203 @@ func TestErrorPropagation(t *testing.T) {
 	assert.Contains(t, err.Error(), expectedError)
 	assert.Nil(t, result)
 }
+
+func TestTypedThenPreservesTypes(t *testing.T) {
+	// Each step keeps its static type, so no assertions are needed
+	count := futures.NewFuture(func() (int, error) {
+		return 21, nil
+	})
+	doubled := futures.Then(count, func(n int) (int, error) {
+		return n * 2, nil
+	})
+	label := futures.Then(doubled, func(n int) (string, error) {
+		return fmt.Sprintf("answer=%d", n), nil
+	})
+
+	result, err := label.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "answer=42", result)
+}
+
+func TestRecoverSubstitutesValue(t *testing.T) {
+	failing := futures.NewFuture(func() (string, error) {
+		return "", fmt.Errorf("cache miss")
+	})
+	recovered := failing.Recover(func(err error) (string, error) {
+		return "fallback", nil
+	})
+
+	result, err := recovered.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", result)
+
+	// Successful results pass through untouched
+	ok := futures.NewFuture(func() (string, error) {
+		return "fresh", nil
+	}).Recover(func(err error) (string, error) {
+		t.Error("handler must not run on success")
+		return "", err
+	})
+	result, err = ok.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh", result)
+}
+
+func TestMapErrTranslatesFailures(t *testing.T) {
+	errNotFound := errors.New("not found")
+	lookup := futures.NewFuture(func() (string, error) {
+		return "", fmt.Errorf("sql: no rows")
+	}).MapErr(func(err error) error {
+		return fmt.Errorf("user 7: %w", errNotFound)
+	})
+
+	_, err := lookup.Result()
+	assert.ErrorIs(t, err, errNotFound)
+	assert.Equal(t, futures.Rejected, lookup.State())
+
+	// A nil translation keeps the original error.
+	kept := futures.Failed[int](errNotFound).MapErr(func(error) error { return nil })
+	_, err = kept.Result()
+	assert.ErrorIs(t, err, errNotFound)
+
+	ok := futures.Resolved(3).MapErr(func(err error) error {
+		t.Error("fn must not run on success")
+		return err
+	})
+	v, err := ok.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+}
+
+func TestFallbackToStartsSecondaryOnlyOnFailure(t *testing.T) {
+	secondaryRuns := 0
+	secondary := func() *futures.Future[string] {
+		return futures.NewFuture(func() (string, error) {
+			secondaryRuns++
+			return "replica", nil
+		})
+	}
+
+	primary := futures.NewFuture(func() (string, error) {
+		return "", fmt.Errorf("primary down")
+	})
+	result, err := primary.FallbackTo(secondary()).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "replica", result)
+	assert.Equal(t, 1, secondaryRuns)
+
+	healthy := futures.NewFuture(func() (string, error) {
+		return "primary", nil
+	})
+	unused := secondary()
+	result, err = healthy.FallbackTo(unused).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", result)
+	assert.Equal(t, futures.Pending, unused.State())
+}
+
+func TestFlatMapFlattensInnerFuture(t *testing.T) {
+	userID := futures.NewFuture(func() (int, error) {
+		return 7, nil
+	})
+	fetchOrders := func(id int) *futures.Future[[]string] {
+		return futures.NewFuture(func() ([]string, error) {
+			time.Sleep(10 * time.Millisecond)
+			return []string{fmt.Sprintf("order-%d", id)}, nil
+		})
+	}
+
+	orders, err := futures.FlatMap(userID, fetchOrders).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"order-7"}, orders)
+
+	inner := futures.NewFuture(func() (int, error) {
+		return 2, fmt.Errorf("inner failed")
+	})
+	_, err = futures.FlatMap(userID, func(int) *futures.Future[int] { return inner }).Result()
+	assert.EqualError(t, err, "inner failed")
+}
+
+func TestFlatMapRecoversPanicsAndRunsOnExecutor(t *testing.T) {
+	var calls atomic.Int32
+	mw := func(ctx context.Context, next futures.Invoker) (any, error) {
+		calls.Add(1)
+		return next(ctx)
+	}
+	e := futures.NewExecutor(1, 4, futures.WithMiddleware(mw))
+	defer e.Shutdown(context.Background())
+
+	parent := futures.Submit(e, func() (int, error) { return 1, nil })
+	_, err := futures.FlatMap(parent, func(int) *futures.Future[int] { panic("boom") }).Result()
+	var pe *futures.PanicError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, "boom", pe.Value)
+	}
+	assert.Equal(t, int32(2), calls.Load(), "fn runs through the executor's middleware")
+
+	_, err = futures.FlatMap(parent, func(int) *futures.Future[int] { return nil }).Result()
+	assert.ErrorIs(t, err, futures.ErrNilFuture)
+}
+
+func TestFlattenCollapsesNestedFuture(t *testing.T) {
+	nested := futures.Resolved(futures.NewFuture(func() (int, error) { return 3, nil }))
+	v, err := futures.Flatten(nested).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	_, err = futures.Flatten(futures.Failed[*futures.Future[int]](fmt.Errorf("outer failed"))).Result()
+	assert.EqualError(t, err, "outer failed")
+
+	_, err = futures.Flatten(futures.Resolved[*futures.Future[int]](nil)).Result()
+	assert.ErrorIs(t, err, futures.ErrNilFuture)
+
+	inner := futures.NewFutureCtx(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	flat := futures.Flatten(futures.Resolved(inner))
+	assert.Eventually(t, func() bool { return inner.State() == futures.Running }, time.Second, time.Millisecond)
+	flat.Cancel()
+	_, err = inner.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
+
+func TestDoneAndTryResult(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.NewFuture(func() (int, error) {
+		<-release
+		return 42, nil
+	})
+	f.Start()
+
+	_, _, ok := f.TryResult()
+	assert.False(t, ok)
+
+	close(release)
+	select {
+	case <-f.Done():
+	case <-time.After(time.Second):
+		t.Fatal("future never settled")
+	}
+
+	result, err, ok := f.TryResult()
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result)
+}
+
+func TestStatePredicates(t *testing.T) {
+	ok := futures.Resolved(1)
+	assert.True(t, ok.IsDone())
+	assert.True(t, ok.IsSuccess())
+	assert.False(t, ok.IsFailed())
+
+	cancelled := futures.NewPromise[int]().Future()
+	assert.False(t, cancelled.IsDone())
+	cancelled.Cancel()
+	assert.True(t, cancelled.IsCancelled())
+	assert.True(t, cancelled.IsFailed())
+
+	assert.Equal(t, "Rejected", futures.Rejected.String())
+	assert.Equal(t, "State(9)", futures.State(9).String())
+}
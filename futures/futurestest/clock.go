@@ -0,0 +1,115 @@
+package futurestest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+// Clock is a futures.Clock whose time only moves when Advance is called, so
+// timeouts, delays and TTLs can be tested without waiting for them.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*timer
+	changed chan struct{} // closed and replaced whenever a timer is added
+}
+
+type timer struct {
+	clock *Clock
+	when  time.Time
+	fire  func()
+}
+
+var _ futures.Clock = (*Clock)(nil)
+
+// NewClock creates a fake clock reading start.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start, changed: make(chan struct{})}
+}
+
+// Now returns the fake current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel receiving the fake time once the clock has been
+// advanced by d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.schedule(d, func(now time.Time) { ch <- now })
+	return ch
+}
+
+// AfterFunc calls f in its own goroutine once the clock has been advanced by
+// d.
+func (c *Clock) AfterFunc(d time.Duration, f func()) futures.Timer {
+	return c.schedule(d, func(time.Time) { go f() })
+}
+
+func (c *Clock) schedule(d time.Duration, fire func(now time.Time)) *timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &timer{clock: c, when: c.now.Add(d)}
+	t.fire = func() { fire(t.when) }
+	c.timers = append(c.timers, t)
+	close(c.changed)
+	c.changed = make(chan struct{})
+	return t
+}
+
+// Advance moves the clock forward by d and fires, in order, every timer that
+// has become due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due, pending []*timer
+	for _, t := range c.timers {
+		if t.when.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			due = append(due, t)
+		}
+	}
+	c.timers = pending
+	c.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].when.Before(due[j].when) })
+	for _, t := range due {
+		t.fire()
+	}
+}
+
+// BlockUntil waits until at least n timers are pending, so a test can advance
+// the clock only once the code under test has started waiting on it.
+func (c *Clock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		count, changed := len(c.timers), c.changed
+		c.mu.Unlock()
+		if count >= n {
+			return
+		}
+		<-changed
+	}
+}
+
+// Stop removes the timer, reporting false if it already fired or was
+// stopped.
+func (t *timer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
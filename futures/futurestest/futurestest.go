@@ -0,0 +1,69 @@
+// Package futurestest provides helpers for testing code built on futures:
+// assertions with deadlines, futures completed on demand, and a fake clock.
+package futurestest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+// RequireResolves fails the test immediately unless f fulfills with a value
+// equal to want (as reflect.DeepEqual) within the given time.
+func RequireResolves[T any](t testing.TB, f *futures.Future[T], want T, within time.Duration) {
+	t.Helper()
+	got, err := f.ResultTimeout(within)
+	switch {
+	case err == futures.ErrTimeout:
+		t.Fatalf("future did not settle within %v", within)
+	case err != nil:
+		t.Fatalf("future rejected with %v, want value %v", err, want)
+	case !reflect.DeepEqual(got, want):
+		t.Fatalf("future resolved with %v, want %v", got, want)
+	}
+}
+
+// RequireRejects fails the test immediately unless f rejects within the given
+// time with an error whose message contains errSubstr.
+func RequireRejects[T any](t testing.TB, f *futures.Future[T], errSubstr string, within time.Duration) {
+	t.Helper()
+	got, err := f.ResultTimeout(within)
+	switch {
+	case err == futures.ErrTimeout:
+		t.Fatalf("future did not settle within %v", within)
+	case err == nil:
+		t.Fatalf("future resolved with %v, want an error containing %q", got, errSubstr)
+	case !strings.Contains(err.Error(), errSubstr):
+		t.Fatalf("future rejected with %q, want an error containing %q", err, errSubstr)
+	}
+}
+
+// ManualFuture is a future that the test settles explicitly, for standing in
+// for a dependency whose timing the test wants to control.
+type ManualFuture[T any] struct {
+	p *futures.Promise[T]
+}
+
+// NewManualFuture creates a pending ManualFuture.
+func NewManualFuture[T any]() *ManualFuture[T] {
+	return &ManualFuture[T]{p: futures.NewPromise[T]()}
+}
+
+// Future returns the future to hand to the code under test.
+func (m *ManualFuture[T]) Future() *futures.Future[T] {
+	return m.p.Future()
+}
+
+// Resolve fulfills the future with v, reporting whether it was still
+// pending.
+func (m *ManualFuture[T]) Resolve(v T) bool {
+	return m.p.Complete(v)
+}
+
+// Reject fails the future with err, reporting whether it was still pending.
+func (m *ManualFuture[T]) Reject(err error) bool {
+	return m.p.Fail(err)
+}
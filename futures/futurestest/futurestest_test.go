@@ -0,0 +1,62 @@
+package futurestest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/futurestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualFuture(t *testing.T) {
+	m := futurestest.NewManualFuture[int]()
+	doubled := futures.Then(m.Future(), func(v int) (int, error) { return v * 2, nil })
+
+	assert.True(t, m.Resolve(21))
+	assert.False(t, m.Resolve(1))
+	futurestest.RequireResolves(t, doubled, 42, time.Second)
+
+	failed := futurestest.NewManualFuture[int]()
+	failed.Reject(errors.New("backend down"))
+	futurestest.RequireRejects(t, failed.Future(), "backend down", time.Second)
+}
+
+func TestClockAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := futurestest.NewClock(start)
+
+	late := c.After(2 * time.Second)
+	fired := make(chan struct{})
+	c.AfterFunc(time.Second, func() { close(fired) })
+	stopped := c.AfterFunc(time.Second, func() { t.Error("stopped timer fired") })
+	assert.True(t, stopped.Stop())
+
+	c.Advance(time.Second)
+	<-fired
+	assert.Equal(t, start.Add(time.Second), c.Now())
+	select {
+	case <-late:
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	c.Advance(time.Second)
+	assert.Equal(t, start.Add(2*time.Second), <-late)
+	assert.False(t, stopped.Stop())
+}
+
+func TestClockBlockUntil(t *testing.T) {
+	c := futurestest.NewClock(time.Now())
+	got := make(chan time.Time)
+	go func() { got <- <-c.After(time.Minute) }()
+
+	c.BlockUntil(1)
+	c.Advance(time.Minute)
+	select {
+	case <-got:
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not woken")
+	}
+}
@@ -0,0 +1,73 @@
+package futures
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChainNode is a read-only view of a future as a stage of a chain, whatever
+// its result type. Every *Future implements it, so chains built dynamically
+// can be walked in either direction.
+type ChainNode interface {
+	Name() string
+	Stage() int
+	State() State
+	Duration() time.Duration
+	Parent() ChainNode
+	Children() []ChainNode
+}
+
+// Name returns the stage name given with ThenNamed, or "stage N".
+func (f *Future[T]) Name() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.label()
+}
+
+// Stage returns the position of the future in its chain, 0 for the root.
+func (f *Future[T]) Stage() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.stage
+}
+
+// Parent returns the future this one was chained from, or nil for a root.
+func (f *Future[T]) Parent() ChainNode {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.parent
+}
+
+// Children returns the stages chained from the future, in the order they
+// were added.
+func (f *Future[T]) Children() []ChainNode {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]ChainNode(nil), f.children...)
+}
+
+// DumpDOT renders the whole chain containing n, from its root, in Graphviz
+// DOT format. Each stage is labelled with its name, state and duration.
+func DumpDOT(n ChainNode) string {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		n = p
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph chain {\n")
+	ids := map[ChainNode]int{n: 0}
+	queue := []ChainNode{n}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		fmt.Fprintf(&b, "\tn%d [label=%q];\n", ids[cur], fmt.Sprintf("%s\n%s %v", cur.Name(), cur.State(), cur.Duration().Round(time.Microsecond)))
+		for _, child := range cur.Children() {
+			ids[child] = len(ids)
+			fmt.Fprintf(&b, "\tn%d -> n%d;\n", ids[cur], ids[child])
+			queue = append(queue, child)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package futures_test
+
+import (
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainGraphAndDOT(t *testing.T) {
+	root := futures.NewFuture(func() (string, error) { return "1", nil })
+	parsed := futures.ThenNamed(root, "parse", func(s string) (int, error) { return len(s), nil })
+	doubled := futures.Then(parsed, func(n int) (int, error) { return n * 2, nil })
+	logged := futures.Then(root, func(s string) (string, error) { return s, nil })
+	_, _ = doubled.Result()
+	_, _ = logged.Result()
+
+	assert.Equal(t, futures.ChainNode(root), logged.Parent())
+	assert.Len(t, root.Children(), 2)
+	assert.Equal(t, "parse", doubled.Parent().Name())
+	assert.Equal(t, 2, doubled.Stage())
+
+	dot := futures.DumpDOT(doubled)
+	assert.Contains(t, dot, "digraph chain {")
+	assert.Contains(t, dot, `n0 [label="stage 0\nFulfilled`)
+	assert.Contains(t, dot, `n1 [label="parse\nFulfilled`)
+	assert.Contains(t, dot, "n0 -> n1;")
+	assert.Contains(t, dot, "n0 -> n2;")
+	assert.Contains(t, dot, "n1 -> n3;")
+}
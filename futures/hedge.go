@@ -0,0 +1,99 @@
+package futures
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Hedged returns a started future for task that guards against slow
+// outliers: if an attempt has not settled within delay, another one is
+// started alongside it, up to maxAttempts in all. The future resolves with
+// the first attempt to succeed, and the attempts still running are then
+// cancelled through their context. A failed attempt is replaced right away
+// while attempts remain; once every attempt has failed, the future rejects
+// with an AggregateError of their errors. Pass WithClock to measure the delay
+// on another clock.
+func Hedged[T any](task func(ctx context.Context) (T, error), delay time.Duration, maxAttempts int, opts ...Option) *Future[T] {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	o := buildOptions(opts)
+	h := &hedge[T]{
+		task:  task,
+		delay: delay,
+		max:   maxAttempts,
+		clock: o.clock,
+		p:     NewPromise[T](),
+	}
+	attachToken(o.token, h.p.future)
+	h.launch()
+	return h.p.Future()
+}
+
+type hedge[T any] struct {
+	task  func(ctx context.Context) (T, error)
+	delay time.Duration
+	max   int
+	clock Clock
+	p     *Promise[T]
+
+	mu       sync.Mutex
+	attempts []*Future[T]
+	errs     []error
+	timer    Timer
+}
+
+// launch starts another attempt, unless the outcome is known or every
+// attempt has been started, and arms the timer for the one after.
+func (h *hedge[T]) launch() {
+	h.mu.Lock()
+	if h.p.future.State().settled() || len(h.attempts) >= h.max {
+		h.mu.Unlock()
+		return
+	}
+	f := NewFutureCtx(context.Background(), h.task)
+	dependOn(h.p.future, f)
+	h.attempts = append(h.attempts, f)
+	if h.timer != nil {
+		h.timer.Stop()
+	}
+	if len(h.attempts) < h.max {
+		h.timer = h.clock.AfterFunc(h.delay, h.launch)
+	}
+	h.mu.Unlock()
+
+	f.Start()
+	f.whenSettled(func() { h.settled(f) })
+}
+
+func (h *hedge[T]) settled(f *Future[T]) {
+	v, err := f.outcome()
+	if err == nil {
+		if h.p.Complete(v) {
+			h.mu.Lock()
+			if h.timer != nil {
+				h.timer.Stop()
+			}
+			attempts := h.attempts
+			h.mu.Unlock()
+			cancelOthers(attempts, f)
+		}
+		return
+	}
+
+	h.mu.Lock()
+	h.errs = append(h.errs, err)
+	failed := len(h.errs) == h.max
+	errs := h.errs
+	if failed && h.timer != nil {
+		h.timer.Stop()
+	}
+	h.mu.Unlock()
+
+	if failed {
+		h.p.Fail(aggregate(errs))
+	} else {
+		h.launch()
+	}
+}
@@ -0,0 +1,58 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/futurestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedgedRacesSlowAttempt(t *testing.T) {
+	clock := futurestest.NewClock(time.Now())
+	var attempts atomic.Int32
+	loserStopped := make(chan struct{})
+	f := futures.Hedged(func(ctx context.Context) (int, error) {
+		if attempts.Add(1) == 1 {
+			<-ctx.Done() // the slow outlier
+			close(loserStopped)
+			return 0, ctx.Err()
+		}
+		return 2, nil
+	}, 50*time.Millisecond, 3, futures.WithClock(clock))
+
+	clock.BlockUntil(1)
+	clock.Advance(50 * time.Millisecond)
+	futurestest.RequireResolves(t, f, 2, time.Second)
+	<-loserStopped
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestHedgedFastAttemptNeedsNoHedge(t *testing.T) {
+	var attempts atomic.Int32
+	v, err := futures.Hedged(func(context.Context) (int, error) {
+		attempts.Add(1)
+		return 1, nil
+	}, time.Hour, 3).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestHedgedRejectsWhenEveryAttemptFails(t *testing.T) {
+	var attempts atomic.Int32
+	boom := errors.New("boom")
+	_, err := futures.Hedged(func(context.Context) (int, error) {
+		attempts.Add(1)
+		return 0, boom
+	}, time.Hour, 3).Result()
+
+	var agg *futures.AggregateError
+	assert.ErrorAs(t, err, &agg)
+	assert.Len(t, agg.Errors, 3)
+	assert.Equal(t, int32(3), attempts.Load())
+}
@@ -0,0 +1,149 @@
+// Package httpx runs HTTP client requests as futures.
+//
+// Requests follow the future: cancelling it cancels the request. The task
+// constructors (DoTask, GetJSONTask) return plain functions, so requests can
+// also be run through futures.Retry or a futures.CircuitBreaker:
+//
+//	policy := futures.RetryIf(futures.ExponentialBackoff(100*time.Millisecond, 2*time.Second), httpx.Retryable)
+//	user := futures.Retry(httpx.GetJSONTask[User](client, url), futures.MaxAttempts(policy, 3))
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+// maxErrorBody bounds how much of an error response is kept in StatusError.
+const maxErrorBody = 4 << 10
+
+// StatusError is returned when a server answers with a status outside the
+// 2xx range.
+type StatusError struct {
+	Code   int    // HTTP status code
+	Status string // Status line, such as "503 Service Unavailable"
+	Body   []byte // Start of the response body
+}
+
+func (e *StatusError) Error() string {
+	return "httpx: unexpected status " + e.Status
+}
+
+// Do sends req with client, or http.DefaultClient if client is nil, and
+// returns a started future for the response. The request runs under req's
+// context, and cancelling the future cancels it. The caller must close the
+// response body.
+func Do(client *http.Client, req *http.Request) *futures.Future[*http.Response] {
+	f := futures.NewFutureCtx(req.Context(), func(ctx context.Context) (*http.Response, error) {
+		return send(ctx, client, req)
+	})
+	f.Start()
+	return f
+}
+
+// GetJSON returns a started future fetching url with client, or
+// http.DefaultClient if client is nil, and decoding the JSON response body
+// into a T. Non-2xx responses reject with a *StatusError. Cancelling the
+// future cancels the request.
+func GetJSON[T any](client *http.Client, url string) *futures.Future[T] {
+	f := futures.NewFutureCtx(context.Background(), func(ctx context.Context) (T, error) {
+		return getJSON[T](ctx, client, url)
+	})
+	f.Start()
+	return f
+}
+
+// DoTask returns a task sending a fresh request from newReq each time it
+// runs, for use with futures.Retry and futures.CircuitBreaker. Non-2xx
+// responses are returned as a *StatusError, with the body closed.
+func DoTask(client *http.Client, newReq func() (*http.Request, error)) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := send(req.Context(), client, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkStatus(resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+}
+
+// GetJSONTask returns a task performing GetJSON's request each time it runs,
+// for use with futures.Retry and futures.CircuitBreaker.
+func GetJSONTask[T any](client *http.Client, url string) func() (T, error) {
+	return func() (T, error) {
+		return getJSON[T](context.Background(), client, url)
+	}
+}
+
+// Retryable reports whether err is worth retrying: a network error, a 429
+// Too Many Requests or a 5xx response. Cancellations and other responses are
+// not.
+func Retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, futures.ErrCancelled) {
+		return false
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Code == http.StatusTooManyRequests || se.Code >= 500
+	}
+	return true
+}
+
+func send(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		// Cancelled as the response arrived: nobody will close it.
+		resp.Body.Close()
+		return nil, ctx.Err()
+	}
+	return resp, nil
+}
+
+func getJSON[T any](ctx context.Context, client *http.Client, url string) (T, error) {
+	var v T
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return v, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := send(ctx, client, req)
+	if err != nil {
+		return v, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return v, err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return v, fmt.Errorf("httpx: decoding %s: %w", url, err)
+	}
+	return v, nil
+}
+
+// checkStatus turns a non-2xx response into a StatusError, closing its body.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return &StatusError{Code: resp.StatusCode, Status: resp.Status, Body: body}
+}
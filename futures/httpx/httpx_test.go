@@ -0,0 +1,76 @@
+package httpx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/httpx"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	Name string `json:"name"`
+}
+
+func TestGetJSONDecodesResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"ada"}`))
+	}))
+	defer srv.Close()
+
+	u, err := httpx.GetJSON[user](srv.Client(), srv.URL).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "ada", u.Name)
+}
+
+func TestGetJSONReportsStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such user", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, err := httpx.GetJSON[user](srv.Client(), srv.URL).Result()
+	var se *httpx.StatusError
+	assert.ErrorAs(t, err, &se)
+	assert.Equal(t, http.StatusNotFound, se.Code)
+	assert.Contains(t, string(se.Body), "no such user")
+	assert.False(t, httpx.Retryable(err))
+}
+
+func TestDoCancelsRequest(t *testing.T) {
+	arrived := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(arrived)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	f := httpx.Do(srv.Client(), req)
+	<-arrived
+	f.Cancel()
+	_, err := f.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
+
+func TestGetJSONTaskRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"name":"grace"}`))
+	}))
+	defer srv.Close()
+
+	policy := futures.RetryIf(futures.ConstantBackoff(time.Millisecond), httpx.Retryable)
+	u, err := futures.Retry(httpx.GetJSONTask[user](srv.Client(), srv.URL), futures.MaxAttempts(policy, 5)).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "grace", u.Name)
+	assert.Equal(t, int32(3), calls.Load())
+}
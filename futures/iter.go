@@ -0,0 +1,46 @@
+package futures
+
+import "iter"
+
+// Completed starts fs and yields each future, together with its error, in the
+// order the futures settle. This lets callers handle early finishers without
+// waiting for the slowest one. Breaking out of the loop leaves the remaining
+// futures running.
+func Completed[T any](fs ...*Future[T]) iter.Seq2[*Future[T], error] {
+	return func(yield func(*Future[T], error) bool) {
+		settled := make(chan *Future[T], len(fs))
+		for _, f := range fs {
+			f.Start()
+			go func() {
+				<-f.doneChan()
+				settled <- f
+			}()
+		}
+
+		for range fs {
+			f := <-settled
+			_, err := f.Result()
+			if !yield(f, err) {
+				return
+			}
+		}
+	}
+}
+
+// Collect starts fs and yields each result in the order the futures settle,
+// for ranging over results directly:
+//
+//	for v, err := range futures.Collect(fs) {
+//		...
+//	}
+//
+// Breaking out of the loop leaves the remaining futures running.
+func Collect[T any](fs []*Future[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for f := range Completed(fs...) {
+			if !yield(f.outcome()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletedYieldsInSettleOrder(t *testing.T) {
+	delayed := func(d time.Duration, v string, err error) *futures.Future[string] {
+		return futures.NewFuture(func() (string, error) {
+			time.Sleep(d)
+			return v, err
+		})
+	}
+	boom := errors.New("boom")
+
+	var order []string
+	var errs []error
+	for f, err := range futures.Completed(
+		delayed(60*time.Millisecond, "slow", nil),
+		delayed(0, "fast", nil),
+		delayed(30*time.Millisecond, "failing", boom),
+	) {
+		v, _ := f.Result()
+		order = append(order, v)
+		errs = append(errs, err)
+	}
+
+	assert.Equal(t, []string{"fast", "", "slow"}, order)
+	assert.Equal(t, []error{nil, boom, nil}, errs)
+}
+
+func TestCollectYieldsResults(t *testing.T) {
+	boom := errors.New("boom")
+	fs := []*futures.Future[int]{
+		after(40*time.Millisecond, 3, nil),
+		after(0, 1, nil),
+		after(20*time.Millisecond, 0, boom),
+	}
+
+	var values []int
+	var errs []error
+	for v, err := range futures.Collect(fs) {
+		values = append(values, v)
+		errs = append(errs, err)
+	}
+	assert.Equal(t, []int{1, 0, 3}, values)
+	assert.Equal(t, []error{nil, boom, nil}, errs)
+}
@@ -0,0 +1,130 @@
+package futures
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// KeyedExecutor runs tasks submitted under the same key one after another,
+// in submission order, while tasks under different keys run in parallel: the
+// usual way to process events per entity (a user, an account, ...) in order
+// without serializing everything.
+type KeyedExecutor[K comparable] struct {
+	exec *Executor
+
+	mu     sync.Mutex
+	queues map[K][]orderedJob // per key with work, the running task first
+}
+
+// orderedJob is a task waiting in a key's queue. dispatch starts it once it
+// heads the queue; the queue moves on when its future settles, or when its
+// task returns if it was already running.
+type orderedJob struct {
+	dispatch func()
+}
+
+// Phases of an ordered job once it heads its key's queue.
+const (
+	jobQueued     int32 = iota // waiting behind earlier tasks
+	jobDispatched              // handed to the executor
+	jobRunning                 // the task is running; it advances the queue
+	jobDone                    // the queue moved on
+)
+
+// NewKeyedExecutor creates a keyed executor running tasks on exec, or on
+// goroutines of their own if exec is nil. The parallelism across keys is
+// bounded by exec's workers.
+func NewKeyedExecutor[K comparable](exec *Executor) *KeyedExecutor[K] {
+	return &KeyedExecutor[K]{exec: exec, queues: make(map[K][]orderedJob)}
+}
+
+// SubmitInOrder queues task under key and returns its future. The task starts
+// once every task submitted earlier under key has returned, including tasks
+// whose futures were cancelled while running. Cancelling the future before
+// the task starts drops it without it ever running. If the executor does not
+// accept the task, the future rejects with its error and the key moves on to
+// its next task.
+//
+// The future is an executor future like Submit's: it goes through the
+// executor's middleware, metrics and logging, records its queue wait and
+// duration, and a panicking task rejects it with a *PanicError instead of
+// holding up the key.
+func SubmitInOrder[K comparable, T any](k *KeyedExecutor[K], key K, task func() (T, error)) *Future[T] {
+	f := newFuture(task, k.exec, options{})
+	f.started = true // dispatched by the key's queue, not by Start
+	var phase atomic.Int32
+	job := orderedJob{dispatch: func() {
+		phase.Store(jobDispatched)
+		// Moves the queue on if the future settles before its task runs:
+		// cancelled while queued, or rejected by the executor.
+		f.whenSettled(func() {
+			if phase.CompareAndSwap(jobDispatched, jobDone) {
+				k.advance(key)
+			}
+		})
+		f.markQueued()
+		run := func() {
+			if f.State().settled() || !f.admitted() {
+				return
+			}
+			if !phase.CompareAndSwap(jobDispatched, jobRunning) {
+				return
+			}
+			f.beginExec()
+			f.complete(f.intercept(f.task))
+			k.advance(key)
+		}
+		if k.exec == nil {
+			go run()
+			return
+		}
+
+		// Never block on the queue: dispatch also runs on workers, after the
+		// previous task.
+		fail := func(err error) {
+			var zero T
+			f.complete(zero, err)
+		}
+		cancel := func() { f.Cancel() }
+		t := execTask{run: run, reject: fail, cancel: cancel}
+		if err := k.exec.submit(t, false); err != nil {
+			fail(err)
+		}
+	}}
+
+	k.mu.Lock()
+	idle := len(k.queues[key]) == 0
+	k.queues[key] = append(k.queues[key], job)
+	k.mu.Unlock()
+
+	if idle {
+		job.dispatch()
+	}
+	return f
+}
+
+// Pending returns the number of tasks under key that have not finished,
+// counting the running one.
+func (k *KeyedExecutor[K]) Pending(key K) int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.queues[key])
+}
+
+// advance drops the head of key's queue and dispatches the next task.
+func (k *KeyedExecutor[K]) advance(key K) {
+	k.mu.Lock()
+	queue := k.queues[key]
+	queue[0] = orderedJob{}
+	queue = queue[1:]
+	if len(queue) == 0 {
+		delete(k.queues, key)
+		k.mu.Unlock()
+		return
+	}
+	k.queues[key] = queue
+	next := queue[0]
+	k.mu.Unlock()
+
+	next.dispatch()
+}
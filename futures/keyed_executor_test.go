@@ -0,0 +1,121 @@
+package futures_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedExecutorOrdersPerKeyAndRunsKeysInParallel(t *testing.T) {
+	e := futures.NewExecutor(4, 64)
+	defer e.Shutdown(context.Background())
+	k := futures.NewKeyedExecutor[string](e)
+
+	// Tasks of one key never overlap, so their slice needs no lock.
+	seen := map[string]*[]int{"alice": new([]int), "bob": new([]int)}
+	var fs []*futures.Future[int]
+	for i := 0; i < 20; i++ {
+		for key, events := range seen {
+			fs = append(fs, futures.SubmitInOrder(k, key, func() (int, error) {
+				time.Sleep(100 * time.Microsecond)
+				*events = append(*events, i)
+				return i, nil
+			}))
+		}
+	}
+	_, err := futures.All(fs...).Result()
+	assert.NoError(t, err)
+	for key, events := range seen {
+		assert.Len(t, *events, 20, key)
+		for i, v := range *events {
+			assert.Equal(t, i, v, key)
+		}
+	}
+
+	// A stuck key holds up only its own tasks.
+	release := make(chan struct{})
+	stuck := futures.SubmitInOrder(k, "alice", func() (int, error) {
+		<-release
+		return 0, nil
+	})
+	after := futures.SubmitInOrder(k, "alice", func() (int, error) { return 1, nil })
+	v, err := futures.SubmitInOrder(k, "bob", func() (int, error) { return 2, nil }).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 2, k.Pending("alice"))
+	assert.False(t, after.IsDone())
+
+	close(release)
+	_, err = futures.All(stuck, after).Result()
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return k.Pending("alice") == 0 }, time.Second, time.Millisecond)
+}
+
+func TestKeyedExecutorSkipsCancelledTasks(t *testing.T) {
+	k := futures.NewKeyedExecutor[int](nil)
+
+	release := make(chan struct{})
+	var runs atomic.Int32
+	first := futures.SubmitInOrder(k, 1, func() (int, error) {
+		<-release
+		runs.Add(1)
+		return 1, nil
+	})
+	dropped := futures.SubmitInOrder(k, 1, func() (int, error) {
+		runs.Add(1)
+		return 2, nil
+	})
+	last := futures.SubmitInOrder(k, 1, func() (int, error) {
+		runs.Add(1)
+		return 3, nil
+	})
+	dropped.Cancel()
+	close(release)
+
+	v, err := last.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	assert.True(t, first.IsDone())
+	_, err = dropped.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	assert.Equal(t, int32(2), runs.Load())
+
+	e := futures.NewExecutor(1, 1)
+	assert.NoError(t, e.Shutdown(context.Background()))
+	_, err = futures.SubmitInOrder(futures.NewKeyedExecutor[int](e), 1, func() (int, error) { return 1, nil }).Result()
+	assert.ErrorIs(t, err, futures.ErrExecutorShutdown)
+}
+
+func TestKeyedExecutorRunsTasksAsExecutorFutures(t *testing.T) {
+	var calls atomic.Int32
+	mw := func(ctx context.Context, next futures.Invoker) (any, error) {
+		calls.Add(1)
+		return next(ctx)
+	}
+	e := futures.NewExecutor(2, 8, futures.WithMiddleware(mw))
+	defer e.Shutdown(context.Background())
+	k := futures.NewKeyedExecutor[string](e)
+
+	// A panicking task rejects its own future and the key moves on.
+	boom := futures.SubmitInOrder(k, "a", func() (int, error) { panic("boom") })
+	next := futures.SubmitInOrder(k, "a", func() (int, error) {
+		time.Sleep(time.Millisecond)
+		return 1, nil
+	})
+	v, err := next.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	_, err = boom.Result()
+	var pe *futures.PanicError
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, "boom", pe.Value)
+
+	assert.Equal(t, int32(2), calls.Load(), "middleware wraps each task")
+	assert.False(t, next.StartedAt().IsZero())
+	assert.GreaterOrEqual(t, next.Duration(), time.Millisecond)
+	assert.Eventually(t, func() bool { return k.Pending("a") == 0 }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,134 @@
+package futures
+
+import (
+	"math"
+	"sync"
+)
+
+// KeyedLimiter bounds the number of in-flight tasks per key (a tenant, an
+// endpoint, ...) as well as in total. When capacity frees up, queued work is
+// picked round-robin across keys, so a burst for one key cannot starve the
+// others sharing the limiter.
+type KeyedLimiter[K comparable] struct {
+	perKey int
+	total  int
+
+	mu       sync.Mutex
+	queues   map[K][]keyedJob
+	ring     []K // keys with queued work, in round-robin order
+	next     int // position in ring of the key to serve next
+	inflight map[K]int
+	running  int
+}
+
+type keyedJob struct {
+	run       func()
+	abandoned func() bool
+}
+
+// NewKeyedLimiter creates a limiter allowing perKey in-flight tasks per key
+// and total in-flight tasks overall. A total of zero or less means no overall
+// limit.
+func NewKeyedLimiter[K comparable](perKey, total int) *KeyedLimiter[K] {
+	if total <= 0 {
+		total = math.MaxInt
+	}
+	return &KeyedLimiter[K]{
+		perKey:   perKey,
+		total:    total,
+		queues:   make(map[K][]keyedJob),
+		inflight: make(map[K]int),
+	}
+}
+
+// SubmitKeyed queues task under key and returns its future. The task starts
+// once both the key and the limiter have spare capacity. Cancelling the
+// future before then drops the task without it ever running.
+func SubmitKeyed[K comparable, T any](l *KeyedLimiter[K], key K, task func() (T, error)) *Future[T] {
+	p := NewPromise[T]()
+	job := keyedJob{
+		run: func() {
+			go func() {
+				res, err := task()
+				p.future.complete(res, err)
+				l.finish(key)
+			}()
+		},
+		abandoned: func() bool {
+			return p.future.State() != Pending
+		},
+	}
+
+	l.mu.Lock()
+	if _, queued := l.queues[key]; !queued {
+		l.ring = append(l.ring, key)
+	}
+	l.queues[key] = append(l.queues[key], job)
+	ready := l.dispatchLocked()
+	l.mu.Unlock()
+
+	for _, j := range ready {
+		j.run()
+	}
+	return p.Future()
+}
+
+// Pending returns the number of tasks queued under key.
+func (l *KeyedLimiter[K]) Pending(key K) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.queues[key])
+}
+
+func (l *KeyedLimiter[K]) finish(key K) {
+	l.mu.Lock()
+	l.running--
+	if l.inflight[key]--; l.inflight[key] == 0 {
+		delete(l.inflight, key)
+	}
+	ready := l.dispatchLocked()
+	l.mu.Unlock()
+
+	for _, j := range ready {
+		j.run()
+	}
+}
+
+// dispatchLocked claims capacity for as many queued jobs as allowed, walking
+// the keys round-robin. It stops after a full pass over the ring without
+// finding a key with spare capacity.
+func (l *KeyedLimiter[K]) dispatchLocked() []keyedJob {
+	var ready []keyedJob
+	scanned := 0
+	for l.running < l.total && len(l.ring) > 0 && scanned < len(l.ring) {
+		if l.next >= len(l.ring) {
+			l.next = 0
+		}
+		key := l.ring[l.next]
+		if l.inflight[key] >= l.perKey {
+			l.next++
+			scanned++
+			continue
+		}
+
+		queue := l.queues[key]
+		job := queue[0]
+		if len(queue) == 1 {
+			// The key has no more work; the next key slides into its slot.
+			delete(l.queues, key)
+			l.ring = append(l.ring[:l.next], l.ring[l.next+1:]...)
+		} else {
+			l.queues[key] = queue[1:]
+			l.next++
+		}
+		scanned = 0
+
+		if job.abandoned() {
+			continue
+		}
+		l.inflight[key]++
+		l.running++
+		ready = append(ready, job)
+	}
+	return ready
+}
@@ -0,0 +1,49 @@
+package futures_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedLimiterIsFairAcrossKeys(t *testing.T) {
+	limiter := futures.NewKeyedLimiter[string](1, 1)
+
+	// Occupy the only slot so that everything below queues up.
+	gate := make(chan struct{})
+	blocker := futures.SubmitKeyed(limiter, "warmup", func() (string, error) {
+		<-gate
+		return "", nil
+	})
+
+	var mu sync.Mutex
+	var order []string
+	task := func(name string) func() (string, error) {
+		return func() (string, error) {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return name, nil
+		}
+	}
+
+	// Tenant a bursts before tenant b shows up.
+	var fs []*futures.Future[string]
+	for _, name := range []string{"a1", "a2", "a3"} {
+		fs = append(fs, futures.SubmitKeyed(limiter, "a", task(name)))
+	}
+	fs = append(fs, futures.SubmitKeyed(limiter, "b", task("b1")))
+	assert.Equal(t, 3, limiter.Pending("a"))
+
+	close(gate)
+	_, _ = blocker.Result()
+	for _, f := range fs {
+		_, err := f.Result()
+		assert.NoError(t, err)
+	}
+
+	// b1 is served right after a1 instead of waiting for a's whole burst.
+	assert.Equal(t, []string{"a1", "b1", "a2", "a3"}, order)
+}
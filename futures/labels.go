@@ -0,0 +1,58 @@
+package futures
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// NewFutureNamed is NewFuture for a future with a name. The name labels the
+// future in StageErrors and timeline recordings, and it is attached, along
+// with the stage and the executor pool, as pprof labels to the goroutines
+// running the future and the stages chained from it, so CPU profiles and
+// goroutine dumps attribute their work to the pipeline:
+//
+//	go tool pprof -tagfocus future=checkout cpu.pprof
+func NewFutureNamed[T any](name string, task func() (T, error), opts ...Option) *Future[T] {
+	o := buildOptions(opts)
+	eager := o.start == StartEager
+	if eager {
+		// Start only once the name is set.
+		o.start = StartOnDemand
+	}
+	f := newFuture(task, nil, o)
+	f.name, f.pipeline = name, name
+	if eager {
+		f.Start()
+	}
+	return f
+}
+
+// profilerLabels returns the pprof labels for the goroutine running f's own
+// work, or false if f carries nothing worth labelling.
+func (f *Future[T]) profilerLabels() (pprof.LabelSet, bool) {
+	var pool string
+	if f.executor != nil {
+		pool = f.executor.name
+	}
+	f.mutex.Lock()
+	pipeline, named := f.pipeline, f.name != ""
+	var stage string
+	if pipeline != "" || named || pool != "" {
+		stage = f.label()
+	}
+	f.mutex.Unlock()
+	if stage == "" {
+		return pprof.LabelSet{}, false
+	}
+	return pprof.Labels("future", pipeline, "stage", stage, "pool", pool), true
+}
+
+// runLabelled runs fn with ctx carrying f's pprof labels, if it has any.
+func (f *Future[T]) runLabelled(ctx context.Context, fn func(ctx context.Context)) {
+	labels, ok := f.profilerLabels()
+	if !ok {
+		fn(ctx)
+		return
+	}
+	pprof.Do(ctx, labels, fn)
+}
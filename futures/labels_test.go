@@ -0,0 +1,49 @@
+package futures_test
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedFutureLabelsItsGoroutines(t *testing.T) {
+	running := make(chan struct{})
+	release := make(chan struct{})
+	f := futures.NewFutureNamed("checkout", func() (int, error) {
+		close(running)
+		<-release
+		return 1, nil
+	})
+	f.Start()
+	<-running
+
+	var dump bytes.Buffer
+	assert.NoError(t, pprof.Lookup("goroutine").WriteTo(&dump, 1))
+	close(release)
+	assert.Contains(t, dump.String(), `"future":"checkout"`)
+	_, err := f.Result()
+	assert.NoError(t, err)
+}
+
+func TestLabelsNameStageAndPool(t *testing.T) {
+	labels := make(chan map[string]string, 8)
+	record := func(ctx context.Context, next futures.Invoker) (any, error) {
+		got := map[string]string{}
+		pprof.ForLabels(ctx, func(k, v string) bool { got[k] = v; return true })
+		labels <- got
+		return next(ctx)
+	}
+	e := futures.NewExecutor(1, 1, futures.WithMiddleware(record), futures.WithPool("io", 1, 1))
+	defer e.Shutdown(context.Background())
+
+	root := futures.Submit(e.Pool("io"), func() (int, error) { return 1, nil })
+	_, err := futures.ThenNamed(root, "double", func(v int) (int, error) { return v * 2, nil }).Result()
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"future": "", "stage": "stage 0", "pool": "io"}, <-labels)
+	assert.Equal(t, map[string]string{"future": "", "stage": "double", "pool": "io"}, <-labels)
+}
@@ -0,0 +1,38 @@
+package futures
+
+// StartPolicy decides when a future's task begins to run.
+type StartPolicy int
+
+const (
+	// StartOnDemand is the default policy. The task starts when Start is
+	// called, when a consumer first waits for the future (Result, Await,
+	// ResultTimeout, ToChannel, or a combinator such as All), or as soon as a
+	// step is chained onto it with Then, Recover, FallbackTo or FlatMap.
+	StartOnDemand StartPolicy = iota
+
+	// StartLazy defers the task until a consumer waits for the future or for
+	// one chained from it. Chaining does not start anything: a whole chain
+	// of lazy stages stays idle until its last future is consumed, and then
+	// runs from the top.
+	StartLazy
+
+	// StartEager starts the task as soon as the future is created.
+	StartEager
+)
+
+// WithStartPolicy makes NewFuture follow p instead of StartOnDemand.
+func WithStartPolicy(p StartPolicy) Option {
+	return func(o *options) {
+		o.start = p
+	}
+}
+
+// Lazy returns a future for task following StartLazy.
+func Lazy[T any](task func() (T, error)) *Future[T] {
+	return NewFuture(task, WithStartPolicy(StartLazy))
+}
+
+// Eager returns a future for task that is already running.
+func Eager[T any](task func() (T, error)) *Future[T] {
+	return NewFuture(task, WithStartPolicy(StartEager))
+}
@@ -0,0 +1,62 @@
+package futures_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazyChainRunsOnlyWhenConsumed(t *testing.T) {
+	var runs atomic.Int32
+	f := futures.Lazy(func() (int, error) {
+		runs.Add(1)
+		return 20, nil
+	})
+	doubled := futures.Then(f, func(n int) (int, error) {
+		runs.Add(1)
+		return n * 2, nil
+	})
+	plusTwo := futures.Then(doubled, func(n int) (int, error) {
+		return n + 2, nil
+	})
+
+	// Building the chain starts nothing.
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(0), runs.Load())
+	assert.Equal(t, futures.Pending, f.State())
+
+	v, err := plusTwo.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestLazyStartedByCombinator(t *testing.T) {
+	f := futures.Lazy(func() (int, error) { return 1, nil })
+	g := futures.FlatMap(f, func(n int) *futures.Future[int] {
+		return futures.Lazy(func() (int, error) { return n + 1, nil })
+	})
+
+	vs, err := futures.All(g).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, vs)
+}
+
+func TestEagerStartsOnConstruction(t *testing.T) {
+	started := make(chan struct{})
+	f := futures.Eager(func() (int, error) {
+		close(started)
+		return 1, nil
+	})
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("eager future did not start")
+	}
+	<-f.Done()
+	assert.Equal(t, futures.Fulfilled, f.State())
+}
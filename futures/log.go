@@ -0,0 +1,91 @@
+package futures
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+type loggerBox struct {
+	l *slog.Logger
+}
+
+var defaultLogger atomic.Pointer[loggerBox]
+
+// SetLogger installs l as the logger of futures created from now on, except
+// those on an executor with its own logger. A nil l, the default, turns
+// package-level logging off.
+//
+// Futures log their creation, start and successful completion at debug
+// level, cancellations at info level, retried attempts at warn level and
+// failures at error level. A failure passed on from an earlier stage of a
+// chain is logged at error level only by the stage that raised it. Records
+// carry the future's name, stage and executor pool.
+func SetLogger(l *slog.Logger) {
+	defaultLogger.Store(&loggerBox{l: l})
+}
+
+// WithLogger logs the futures of an executor, including stages chained from
+// them, to l instead of the package-level logger. See SetLogger for what is
+// logged.
+func WithLogger(l *slog.Logger) ExecutorOption {
+	return func(e *Executor) {
+		e.logger = l
+	}
+}
+
+// loggerFor returns the logger for a future running on exec.
+func loggerFor(exec *Executor) *slog.Logger {
+	if exec != nil && exec.logger != nil {
+		return exec.logger
+	}
+	if b := defaultLogger.Load(); b != nil {
+		return b.l
+	}
+	return nil
+}
+
+// log records msg with f's name, stage and pool, if f has a logger enabled
+// for level. It must not be called with f.mutex held.
+func (f *Future[T]) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	ctx := context.Background()
+	if f.logger == nil || !f.logger.Enabled(ctx, level) {
+		return
+	}
+	f.mutex.Lock()
+	base := []slog.Attr{slog.String("stage", f.label())}
+	if f.pipeline != "" {
+		base = append(base, slog.String("future", f.pipeline))
+	}
+	f.mutex.Unlock()
+	if f.executor != nil && f.executor.name != "" {
+		base = append(base, slog.String("pool", f.executor.name))
+	}
+	f.logger.LogAttrs(ctx, level, msg, append(base, attrs...)...)
+}
+
+// logSettled records how f settled, given how long its task ran.
+func (f *Future[T]) logSettled(state State, err error, exec time.Duration) {
+	switch state {
+	case Fulfilled:
+		f.log(slog.LevelDebug, "future fulfilled", slog.Duration("duration", exec))
+	case Cancelled:
+		f.log(slog.LevelInfo, "future cancelled", slog.Any("error", err))
+	default:
+		level := slog.LevelError
+		if !f.raised() {
+			level = slog.LevelDebug
+		}
+		f.log(level, "future failed", slog.Duration("duration", exec), slog.Any("error", err))
+	}
+}
+
+// raised reports whether f failed on its own account rather than passing on
+// the failure of the stage it was chained from.
+func (f *Future[T]) raised() bool {
+	f.mutex.Lock()
+	parent := f.parent
+	f.mutex.Unlock()
+	return parent == nil || parent.State() != Rejected
+}
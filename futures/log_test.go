@@ -0,0 +1,60 @@
+package futures_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writes of a logger.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.Split(strings.TrimSpace(b.buf.String()), "\n")
+}
+
+func TestExecutorLoggerRecordsLifecycle(t *testing.T) {
+	var out syncBuffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" || a.Key == "queue_wait" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	e := futures.NewExecutor(1, 1, futures.WithLogger(logger))
+	defer e.Shutdown(context.Background())
+
+	root := futures.Submit(e, func() (int, error) { return 1, nil })
+	parsed := futures.ThenNamed(root, "parse", func(int) (int, error) { return 0, errors.New("bad input") })
+	saved := futures.Then(parsed, func(v int) (int, error) { return v, nil })
+	_, err := saved.Result()
+	assert.Error(t, err)
+
+	assert.Eventually(t, func() bool { return len(out.lines()) == 9 }, time.Second, time.Millisecond)
+	lines := out.lines()
+	assert.Contains(t, lines, `level=DEBUG msg="future fulfilled" stage="stage 0"`)
+	assert.Contains(t, lines, `level=ERROR msg="future failed" stage=parse error="stage 1 (parse): bad input"`)
+	assert.Contains(t, lines, `level=DEBUG msg="future failed" stage="stage 2" error="stage 1 (parse): bad input"`)
+}
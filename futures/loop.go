@@ -0,0 +1,24 @@
+package futures
+
+import "slices"
+
+// NewEventLoop creates an executor running its tasks, including the stages
+// and callbacks of the futures submitted to it, one at a time on a single
+// goroutine, in the order they were submitted. This makes scheduling
+// deterministic for simulations, replays and game loops, as long as tasks do
+// not start work elsewhere, such as futures created with NewFuture or tasks
+// submitted to the executor's pools, which run on workers of their own.
+//
+// Priorities and deadlines set with SubmitPriority and SubmitDeadline are
+// ignored, and OverflowCallerRuns is treated as OverflowBlock, since both
+// would break the submission order. queueSize bounds the tasks waiting, as
+// for NewExecutor.
+func NewEventLoop(queueSize int, opts ...ExecutorOption) *Executor {
+	loop := func(e *Executor) {
+		e.fifo = true
+		if e.overflow == OverflowCallerRuns {
+			e.overflow = OverflowBlock
+		}
+	}
+	return NewExecutor(1, queueSize, append(slices.Clip(opts), loop, WithMinIdle(1))...)
+}
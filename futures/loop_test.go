@@ -0,0 +1,42 @@
+package futures_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventLoopRunsSeriallyInSubmissionOrder(t *testing.T) {
+	loop := futures.NewEventLoop(64)
+	defer loop.Shutdown(context.Background())
+
+	// Only the loop goroutine touches events, so no lock is needed.
+	var events []string
+	gate := make(chan struct{})
+	first := futures.Submit(loop, func() (int, error) {
+		<-gate
+		events = append(events, "task 0")
+		return 0, nil
+	})
+	first.OnSuccess(func(int) { events = append(events, "callback 0") })
+	next := futures.Then(first, func(int) (int, error) {
+		events = append(events, "then 0")
+		return 0, nil
+	})
+	var last *futures.Future[int]
+	for i := 1; i <= 3; i++ {
+		last = futures.SubmitPriority(loop, i, func() (int, error) {
+			events = append(events, fmt.Sprintf("task %d", i))
+			return i, nil
+		})
+	}
+	close(gate)
+
+	_, err := futures.All(next, last).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"task 0", "callback 0", "task 1", "task 2", "task 3", "then 0"}, events)
+	assert.Equal(t, 1, loop.Workers())
+}
@@ -0,0 +1,119 @@
+package futures
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+)
+
+// MapConcurrent returns a started future applying fn to every item, running
+// at most maxParallel calls at a time (all at once if maxParallel is zero or
+// less). The results are in input order.
+//
+// By default the first failure cancels the context passed to the remaining
+// calls, skips items not yet started, and rejects the future with that error.
+// With ContinueOnError every item is processed and the future rejects with
+// an AggregateError holding all the failures; use AllSettled over individual
+// futures to keep partial results instead. A panic in fn stops the work in
+// either case and rejects the future with a *PanicError.
+func MapConcurrent[A, B any](ctx context.Context, items []A, fn func(ctx context.Context, item A) (B, error), maxParallel int, opts ...Option) *Future[[]B] {
+	o := buildOptions(opts)
+	f := NewFutureCtx(ctx, func(ctx context.Context) ([]B, error) {
+		results := make([]B, len(items))
+		err := forEachIndex(ctx, len(items), maxParallel, o.keepGoing, func(ctx context.Context, i int) (err error) {
+			results[i], err = fn(ctx, items[i])
+			return err
+		})
+		if err != nil && !o.keepGoing {
+			return nil, err
+		}
+		return results, err
+	})
+	attachToken(o.token, f)
+	f.Start()
+	return f
+}
+
+// ForEach returns a started future running fn on every item, at most
+// maxParallel calls at a time (all at once if maxParallel is zero or less),
+// that fulfills once all of them have returned. Failures are handled as by
+// MapConcurrent: the first one stops the remaining work and rejects the
+// future, unless ContinueOnError is given, in which case every item is
+// processed and the future rejects with an AggregateError of all failures.
+// A panic in fn is handled as by MapConcurrent.
+func ForEach[A any](ctx context.Context, items []A, fn func(ctx context.Context, item A) error, maxParallel int, opts ...Option) *Future[struct{}] {
+	o := buildOptions(opts)
+	f := NewFutureCtx(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, forEachIndex(ctx, len(items), maxParallel, o.keepGoing, func(ctx context.Context, i int) error {
+			return fn(ctx, items[i])
+		})
+	})
+	attachToken(o.token, f)
+	f.Start()
+	return f
+}
+
+// forEachIndex calls fn for the indices 0 to n-1, at most maxParallel at a
+// time. Unless keepGoing is set, the first failure cancels the context passed
+// to the remaining calls, skips indices not yet started and is returned;
+// otherwise every index is processed and the failures are aggregated. A
+// panicking call always stops the work like a failure without keepGoing,
+// and is returned as a PanicError.
+func forEachIndex(ctx context.Context, n, maxParallel int, keepGoing bool, fn func(ctx context.Context, i int) error) error {
+	if maxParallel <= 0 || maxParallel > n {
+		maxParallel = n
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, n)
+	var firstErr error
+	var once sync.Once
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for range maxParallel {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				var panicked bool
+				errs[i], panicked = callIndex(ctx, i, fn)
+				if errs[i] != nil && (!keepGoing || panicked) {
+					once.Do(func() {
+						firstErr = errs[i]
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range n {
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if keepGoing {
+		return aggregate(errs)
+	}
+	return ctx.Err()
+}
+
+// callIndex calls fn for i, turning a panic into a PanicError.
+func callIndex(ctx context.Context, i int, fn func(ctx context.Context, i int) error) (err error, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, panicked = &PanicError{Value: r, Stack: debug.Stack()}, true
+		}
+	}()
+	return fn(ctx, i), false
+}
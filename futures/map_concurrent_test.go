@@ -0,0 +1,145 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapConcurrentKeepsOrderWithinLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	items := []int{5, 1, 4, 2, 3}
+
+	f := futures.MapConcurrent(context.Background(), items, func(_ context.Context, n int) (int, error) {
+		cur := running.Add(1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		running.Add(-1)
+		return n * 10, nil
+	}, 2)
+
+	out, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{50, 10, 40, 20, 30}, out)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestMapConcurrentFailureCancelsRest(t *testing.T) {
+	boom := errors.New("boom")
+	var calls atomic.Int32
+	items := make([]int, 100)
+
+	f := futures.MapConcurrent(context.Background(), items, func(ctx context.Context, _ int) (int, error) {
+		if calls.Add(1) == 1 {
+			return 0, boom
+		}
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, 4)
+
+	_, err := f.Result()
+	assert.ErrorIs(t, err, boom)
+	assert.Less(t, calls.Load(), int32(100))
+}
+
+func TestMapConcurrentContinueOnError(t *testing.T) {
+	var calls atomic.Int32
+	f := futures.MapConcurrent(context.Background(), []int{1, 2, 3}, func(_ context.Context, n int) (int, error) {
+		calls.Add(1)
+		if n%2 == 1 {
+			return 0, errors.New("odd")
+		}
+		return n, nil
+	}, 1, futures.ContinueOnError())
+
+	_, err := f.Result()
+	assert.EqualError(t, err, "futures: 2 errors: odd; odd")
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestMapConcurrentRecoversPanics(t *testing.T) {
+	for _, opts := range [][]futures.Option{nil, {futures.ContinueOnError()}} {
+		var calls atomic.Int32
+		items := make([]int, 100)
+		f := futures.MapConcurrent(context.Background(), items, func(ctx context.Context, _ int) (int, error) {
+			if calls.Add(1) == 1 {
+				panic("boom")
+			}
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}, 4, opts...)
+
+		_, err := f.Result()
+		var pe *futures.PanicError
+		if assert.ErrorAs(t, err, &pe) {
+			assert.Equal(t, "boom", pe.Value)
+		}
+		assert.Less(t, calls.Load(), int32(100), "the panic cancels the remaining calls")
+	}
+
+	_, err := futures.ForEach(context.Background(), []int{1}, func(context.Context, int) error { panic("boom") }, 1).Result()
+	var pe *futures.PanicError
+	assert.ErrorAs(t, err, &pe)
+}
+
+func TestForEachStopsOnFirstError(t *testing.T) {
+	var done, peak, running atomic.Int32
+	items := make([]int, 20)
+	f := futures.ForEach(context.Background(), items, func(context.Context, int) error {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		done.Add(1)
+		return nil
+	}, 3)
+	_, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(20), done.Load())
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+
+	boom := errors.New("boom")
+	var calls atomic.Int32
+	f = futures.ForEach(context.Background(), make([]int, 100), func(ctx context.Context, _ int) error {
+		if calls.Add(1) == 1 {
+			return boom
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}, 4)
+	_, err = f.Result()
+	assert.ErrorIs(t, err, boom)
+	assert.Less(t, calls.Load(), int32(100))
+}
+
+func TestForEachContinueOnErrorAggregates(t *testing.T) {
+	var calls atomic.Int32
+	f := futures.ForEach(context.Background(), []int{1, 2, 3, 4}, func(_ context.Context, n int) error {
+		calls.Add(1)
+		if n > 2 {
+			return errors.New("too big")
+		}
+		return nil
+	}, 2, futures.ContinueOnError())
+
+	_, err := f.Result()
+	var agg *futures.AggregateError
+	assert.ErrorAs(t, err, &agg)
+	assert.Len(t, agg.Errors, 2)
+	assert.Equal(t, int32(4), calls.Load())
+}
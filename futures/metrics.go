@@ -0,0 +1,60 @@
+package futures
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// MetricsCollector receives lifecycle events of futures, for export to a
+// monitoring system. Implementations must be safe for concurrent use and
+// should return quickly, as they are called inline.
+type MetricsCollector interface {
+	// FutureCreated is called when a future is constructed.
+	FutureCreated()
+	// FutureStarted is called when a task begins its own work, with how long
+	// it waited for a worker or, for a chained stage, since its parent settled.
+	FutureStarted(queueWait time.Duration)
+	// FutureSettled is called once a future has settled, with its final state
+	// and how long its task ran. The duration is zero for futures that never
+	// ran a task, such as promises and futures cancelled before starting.
+	FutureSettled(state State, execDuration time.Duration)
+}
+
+// PoolMetricsCollector is a MetricsCollector that can report the pools of an
+// executor separately. Pool calls ForPool once, when the pool is created.
+type PoolMetricsCollector interface {
+	MetricsCollector
+	ForPool(name string) MetricsCollector
+}
+
+type collectorBox struct {
+	c MetricsCollector
+}
+
+var defaultMetrics atomic.Pointer[collectorBox]
+
+// SetMetricsCollector installs c as the collector of futures created from
+// now on, except those on an executor with its own collector. A nil c turns
+// package-level metrics off.
+func SetMetricsCollector(c MetricsCollector) {
+	defaultMetrics.Store(&collectorBox{c: c})
+}
+
+// WithMetrics reports the futures of an executor, including stages chained
+// from them, to c instead of the package-level collector.
+func WithMetrics(c MetricsCollector) ExecutorOption {
+	return func(e *Executor) {
+		e.metrics = c
+	}
+}
+
+// collectorFor returns the collector for a future running on exec.
+func collectorFor(exec *Executor) MetricsCollector {
+	if exec != nil && exec.metrics != nil {
+		return exec.metrics
+	}
+	if b := defaultMetrics.Load(); b != nil {
+		return b.c
+	}
+	return nil
+}
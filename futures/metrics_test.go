@@ -0,0 +1,54 @@
+package futures_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingMetrics struct {
+	mu      sync.Mutex
+	created int
+	started int
+	settled []futures.State
+}
+
+func (m *recordingMetrics) FutureCreated() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.created++
+}
+
+func (m *recordingMetrics) FutureStarted(time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started++
+}
+
+func (m *recordingMetrics) FutureSettled(s futures.State, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settled = append(m.settled, s)
+}
+
+func TestMetricsCollectorSeesLifecycle(t *testing.T) {
+	m := &recordingMetrics{}
+	futures.SetMetricsCollector(m)
+	t.Cleanup(func() { futures.SetMetricsCollector(nil) })
+
+	f := futures.NewFuture(func() (int, error) { return 1, nil })
+	g := futures.Then(f, func(n int) (int, error) { return n + 1, nil })
+	_, _ = g.Result()
+
+	cancelled := futures.NewPromise[int]().Future()
+	cancelled.Cancel()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	assert.Equal(t, 3, m.created)
+	assert.Equal(t, 2, m.started)
+	assert.ElementsMatch(t, []futures.State{futures.Fulfilled, futures.Fulfilled, futures.Cancelled}, m.settled)
+}
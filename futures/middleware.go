@@ -0,0 +1,88 @@
+package futures
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Invoker runs one unit of work on behalf of a future: its task, a chained
+// stage such as Then, or a callback. Callbacks report a nil value.
+type Invoker func(ctx context.Context) (any, error)
+
+// Middleware wraps the work of futures for cross-cutting concerns such as
+// logging, tracing or panic recovery. It must call next to run the work, and
+// may replace the context passed on, which reaches tasks created with
+// NewFutureCtx. For a task or a stage, the returned value and error become
+// the future's outcome; for a callback they are discarded.
+type Middleware func(ctx context.Context, next Invoker) (any, error)
+
+var (
+	middlewareMu      sync.Mutex
+	defaultMiddleware atomic.Pointer[[]Middleware]
+)
+
+// Use appends mw to the middleware applied to futures created from now on.
+// The first middleware is the outermost one. Package-level middleware wraps
+// the middleware of an executor.
+func Use(mw ...Middleware) {
+	middlewareMu.Lock()
+	defer middlewareMu.Unlock()
+
+	var chain []Middleware
+	if cur := defaultMiddleware.Load(); cur != nil {
+		chain = append(chain, *cur...)
+	}
+	chain = append(chain, mw...)
+	defaultMiddleware.Store(&chain)
+}
+
+// WithMiddleware applies mw to the futures of an executor and to the stages
+// chained from them, inside any package-level middleware.
+func WithMiddleware(mw ...Middleware) ExecutorOption {
+	return func(e *Executor) {
+		e.middleware = append(e.middleware, mw...)
+	}
+}
+
+// middlewareFor returns the middleware of a future running on exec.
+func middlewareFor(exec *Executor) []Middleware {
+	var global []Middleware
+	if cur := defaultMiddleware.Load(); cur != nil {
+		global = *cur
+	}
+	if exec == nil || len(exec.middleware) == 0 {
+		return global
+	}
+	if len(global) == 0 {
+		return exec.middleware
+	}
+	return append(global[:len(global):len(global)], exec.middleware...)
+}
+
+// intercept runs call through mws.
+func intercept[T any](mws []Middleware, ctx context.Context, call func(context.Context) (T, error)) (T, error) {
+	if len(mws) == 0 {
+		return call(ctx)
+	}
+
+	next := Invoker(func(ctx context.Context) (any, error) {
+		return call(ctx)
+	})
+	for i := len(mws) - 1; i >= 0; i-- {
+		mw, inner := mws[i], next
+		next = func(ctx context.Context) (any, error) {
+			return mw(ctx, inner)
+		}
+	}
+
+	out, err := next(ctx)
+	res, ok := out.(T)
+	if !ok && out != nil {
+		var zero T
+		return zero, fmt.Errorf("futures: middleware returned %T, want %v", out, reflect.TypeFor[T]())
+	}
+	return res, err
+}
@@ -0,0 +1,43 @@
+package futures_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutorMiddlewareWrapsTasksStagesAndCallbacks(t *testing.T) {
+	var calls atomic.Int32
+	count := func(ctx context.Context, next futures.Invoker) (any, error) {
+		calls.Add(1)
+		return next(ctx)
+	}
+	recoverPanics := func(ctx context.Context, next futures.Invoker) (v any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return next(ctx)
+	}
+	e := futures.NewExecutor(2, 4, futures.WithMiddleware(count, recoverPanics))
+
+	f := futures.Submit(e, func() (int, error) { return 20, nil })
+	g := futures.Then(f, func(n int) (int, error) { return n * 2, nil })
+	seen := make(chan int, 1)
+	g.OnSuccess(func(n int) { seen <- n })
+
+	v, err := g.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 40, v)
+	assert.Equal(t, 40, <-seen)
+	// The task, the stage and the callback each went through the chain.
+	assert.Equal(t, int32(3), calls.Load())
+
+	_, err = futures.Submit(e, func() (int, error) { panic("boom") }).Result()
+	assert.EqualError(t, err, "panic: boom")
+}
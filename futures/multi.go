@@ -0,0 +1,138 @@
+package futures
+
+// Tuple2 carries the two results of a Future2.
+type Tuple2[A, B any] struct {
+	V1 A
+	V2 B
+}
+
+// Tuple3 carries the three results of a Future3.
+type Tuple3[A, B, C any] struct {
+	V1 A
+	V2 B
+	V3 C
+}
+
+// Future2 is a future for a computation returning two values, matching the
+// common Go shape func() (A, B, error) without a throwaway struct.
+type Future2[A, B any] struct {
+	f *Future[Tuple2[A, B]]
+}
+
+// NewFuture2 creates a new Future2 instance.
+func NewFuture2[A, B any](task func() (A, B, error)) *Future2[A, B] {
+	return &Future2[A, B]{f: NewFuture(func() (Tuple2[A, B], error) {
+		a, b, err := task()
+		return Tuple2[A, B]{a, b}, err
+	})}
+}
+
+// Start starts the future by executing the task asynchronously.
+func (f *Future2[A, B]) Start() {
+	f.f.Start()
+}
+
+// Result waits for the computation and returns both values.
+func (f *Future2[A, B]) Result() (A, B, error) {
+	t, err := f.f.Result()
+	return t.V1, t.V2, err
+}
+
+// State returns the current state of the future.
+func (f *Future2[A, B]) State() State {
+	return f.f.State()
+}
+
+// Cancel cancels the future; see Future.Cancel.
+func (f *Future2[A, B]) Cancel() bool {
+	return f.f.Cancel()
+}
+
+// OnSuccess registers a callback function to be called when the future completes successfully.
+func (f *Future2[A, B]) OnSuccess(cb func(A, B)) *Subscription {
+	return f.f.OnSuccess(func(t Tuple2[A, B]) { cb(t.V1, t.V2) })
+}
+
+// OnFailure registers a callback function to be called when the future completes with an error.
+func (f *Future2[A, B]) OnFailure(cb func(error)) *Subscription {
+	return f.f.OnFailure(cb)
+}
+
+// Future returns the underlying single-value future, for use with APIs that
+// take a *Future.
+func (f *Future2[A, B]) Future() *Future[Tuple2[A, B]] {
+	return f.f
+}
+
+// Map2 chains fn after f, passing both of its values.
+func Map2[A, B, U any](f *Future2[A, B], fn func(A, B) (U, error)) *Future[U] {
+	return NewFuture(func() (U, error) {
+		a, b, err := f.Result()
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return fn(a, b)
+	})
+}
+
+// Future3 is a future for a computation returning three values.
+type Future3[A, B, C any] struct {
+	f *Future[Tuple3[A, B, C]]
+}
+
+// NewFuture3 creates a new Future3 instance.
+func NewFuture3[A, B, C any](task func() (A, B, C, error)) *Future3[A, B, C] {
+	return &Future3[A, B, C]{f: NewFuture(func() (Tuple3[A, B, C], error) {
+		a, b, c, err := task()
+		return Tuple3[A, B, C]{a, b, c}, err
+	})}
+}
+
+// Start starts the future by executing the task asynchronously.
+func (f *Future3[A, B, C]) Start() {
+	f.f.Start()
+}
+
+// Result waits for the computation and returns all three values.
+func (f *Future3[A, B, C]) Result() (A, B, C, error) {
+	t, err := f.f.Result()
+	return t.V1, t.V2, t.V3, err
+}
+
+// State returns the current state of the future.
+func (f *Future3[A, B, C]) State() State {
+	return f.f.State()
+}
+
+// Cancel cancels the future; see Future.Cancel.
+func (f *Future3[A, B, C]) Cancel() bool {
+	return f.f.Cancel()
+}
+
+// OnSuccess registers a callback function to be called when the future completes successfully.
+func (f *Future3[A, B, C]) OnSuccess(cb func(A, B, C)) *Subscription {
+	return f.f.OnSuccess(func(t Tuple3[A, B, C]) { cb(t.V1, t.V2, t.V3) })
+}
+
+// OnFailure registers a callback function to be called when the future completes with an error.
+func (f *Future3[A, B, C]) OnFailure(cb func(error)) *Subscription {
+	return f.f.OnFailure(cb)
+}
+
+// Future returns the underlying single-value future.
+func (f *Future3[A, B, C]) Future() *Future[Tuple3[A, B, C]] {
+	return f.f
+}
+
+// Map3 chains fn after f, passing all three of its values.
+func Map3[A, B, C, U any](f *Future3[A, B, C], fn func(A, B, C) (U, error)) *Future[U] {
+	return NewFuture(func() (U, error) {
+		a, b, c, err := f.Result()
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return fn(a, b, c)
+	})
+}
@@ -0,0 +1,70 @@
+package futures_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuture2ReturnsBothValues(t *testing.T) {
+	f := futures.NewFuture2(func() (string, int, error) {
+		return "answer", 42, nil
+	})
+
+	name, n, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "answer", name)
+	assert.Equal(t, 42, n)
+
+	label, err := futures.Map2(f, func(name string, n int) (string, error) {
+		return name + "=" + strconv.Itoa(n), nil
+	}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "answer=42", label)
+}
+
+func TestFuture3PropagatesError(t *testing.T) {
+	f := futures.NewFuture3(func() (int, int, int, error) {
+		return 0, 0, 0, fmt.Errorf("no triple")
+	})
+
+	_, err := futures.Map3(f, func(a, b, c int) (int, error) {
+		t.Error("must not run")
+		return a + b + c, nil
+	}).Result()
+	assert.EqualError(t, err, "no triple")
+}
+
+func TestZipCombinesDifferentTypes(t *testing.T) {
+	user := futures.NewFuture(func() (string, error) { return "gopher", nil })
+	age := futures.NewFuture(func() (int, error) { return 13, nil })
+	admin := futures.NewFuture(func() (bool, error) { return true, nil })
+
+	name, years, err := futures.Zip2(user, age).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "gopher", name)
+	assert.Equal(t, 13, years)
+
+	tuple, err := futures.Zip3(user, age, admin).Future().Result()
+	assert.NoError(t, err)
+	assert.Equal(t, futures.Tuple3[string, int, bool]{V1: "gopher", V2: 13, V3: true}, tuple)
+}
+
+func TestJoinRejectsOnFirstFailure(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	slow := futures.NewFuture(func() (string, error) {
+		<-release
+		return "late", nil
+	})
+	failing := futures.NewFuture(func() (int, error) { return 0, fmt.Errorf("no quota") })
+
+	_, err := futures.Join(slow, failing).Result()
+	assert.EqualError(t, err, "no quota")
+
+	_, _, err = futures.Zip2(futures.NewFuture(func() (int, error) { return 1, nil }), failing).Result()
+	assert.EqualError(t, err, "no quota")
+}
@@ -0,0 +1,55 @@
+package futures
+
+import "time"
+
+// Option customises the helpers that accept it.
+type Option func(*options)
+
+type options struct {
+	clock Clock
+	start StartPolicy
+	ttl   time.Duration
+	// keepGoing makes fan-out helpers run every item despite failures.
+	keepGoing bool
+	overlap   OverlapPolicy
+	token     *CancelToken // see WithCancelToken
+}
+
+// WithClock makes time-based helpers use c instead of SystemClock.
+func WithClock(c Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithTTL bounds how long helpers that cache results keep them. Zero, the
+// default, keeps them until they are invalidated.
+func WithTTL(d time.Duration) Option {
+	return func(o *options) {
+		o.ttl = d
+	}
+}
+
+// ContinueOnError makes fan-out helpers such as MapConcurrent process every
+// item even after one fails, instead of cancelling the outstanding work.
+func ContinueOnError() Option {
+	return func(o *options) {
+		o.keepGoing = true
+	}
+}
+
+// WithOverlap sets what a Scheduler does when a run is due while the previous
+// one is still going. The default is OverlapSkip.
+func WithOverlap(p OverlapPolicy) Option {
+	return func(o *options) {
+		o.overlap = p
+	}
+}
+
+func buildOptions(opts []Option) options {
+	o := options{clock: SystemClock}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,46 @@
+package futures
+
+import "container/heap"
+
+// OverflowPolicy is what an executor does with work submitted while its
+// queue is full.
+type OverflowPolicy int
+
+const (
+	OverflowBlock      OverflowPolicy = iota // Wait for queue space
+	OverflowReject                           // Fail the new work with ErrQueueFull
+	OverflowDropOldest                       // Fail the oldest queued task with ErrQueueFull to make room
+	OverflowCallerRuns                       // Run the new work on the submitting goroutine
+)
+
+// WithOverflow sets how the executor handles a full queue. The default,
+// OverflowBlock, makes submitters wait; the other policies shed or push back
+// load instead, so a saturated executor degrades rather than piling up
+// blocked goroutines. Stages chained from the executor's futures are subject
+// to the policy too.
+func WithOverflow(p OverflowPolicy) ExecutorOption {
+	return func(e *Executor) {
+		e.overflow = p
+	}
+}
+
+// fullLocked reports whether one more task would have to wait beyond the
+// queue's capacity, counting the workers that are idle or could be started.
+func (e *Executor) fullLocked() bool {
+	backlog := e.waiting + 1 - (e.maxWorkersLocked() - e.busy)
+	return backlog > cap(e.queue)
+}
+
+// removeOldest takes the earliest submitted task out of the queue.
+func (q *taskQueue) removeOldest() (execTask, bool) {
+	if len(q.tasks) == 0 {
+		return execTask{}, false
+	}
+	oldest := 0
+	for i, t := range q.tasks {
+		if t.seq < q.tasks[oldest].seq {
+			oldest = i
+		}
+	}
+	return heap.Remove((*taskHeap)(q), oldest).(execTask), true
+}
@@ -0,0 +1,50 @@
+package futures_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+// saturate fills an executor with one worker and a queue of one: a running
+// task held until release is closed, and a queued one.
+func saturate(t *testing.T, policy futures.OverflowPolicy) (exec *futures.Executor, queued *futures.Future[int], release chan struct{}) {
+	exec = futures.NewExecutor(1, 1, futures.WithOverflow(policy))
+	release = make(chan struct{})
+	running := futures.Submit(exec, func() (int, error) { <-release; return 0, nil })
+	assert.Eventually(t, func() bool { return !running.Queued() }, time.Second, time.Millisecond)
+	queued = futures.Submit(exec, func() (int, error) { return 1, nil })
+	return exec, queued, release
+}
+
+func TestOverflowReject(t *testing.T) {
+	exec, queued, release := saturate(t, futures.OverflowReject)
+	_, err := futures.Submit(exec, func() (int, error) { return 2, nil }).Result()
+	assert.ErrorIs(t, err, futures.ErrQueueFull)
+	assert.ErrorIs(t, exec.Go(func() {}), futures.ErrQueueFull)
+
+	close(release)
+	assert.Equal(t, 1, queued.MustResult())
+}
+
+func TestOverflowDropOldest(t *testing.T) {
+	exec, queued, release := saturate(t, futures.OverflowDropOldest)
+	newest := futures.Submit(exec, func() (int, error) { return 2, nil })
+	_, err := queued.Result()
+	assert.ErrorIs(t, err, futures.ErrQueueFull)
+
+	close(release)
+	assert.Equal(t, 2, newest.MustResult())
+}
+
+func TestOverflowCallerRuns(t *testing.T) {
+	exec, queued, release := saturate(t, futures.OverflowCallerRuns)
+	f := futures.Submit(exec, func() (int, error) { return 2, nil })
+	assert.True(t, f.IsDone())
+	assert.Equal(t, 2, f.MustResult())
+
+	close(release)
+	assert.Equal(t, 1, queued.MustResult())
+}
@@ -0,0 +1,97 @@
+package futures
+
+import "time"
+
+// Stage is a named, typed step of a pipeline, turning an A into a B. A stage
+// is configured once and may then be applied to any number of futures, with
+// Apply or the Pipe functions:
+//
+//	parse := futures.NewStage("parse", parseDocument).WithTimeout(time.Second)
+//	store := futures.NewStage("store", saveDocument).
+//		WithRetry(futures.MaxAttempts(futures.ConstantBackoff(time.Second), 3)).
+//		WithConcurrency(4)
+//	saved := futures.Pipe2(fetched, parse, store)
+//
+// Each application is a stage of the chain, as with ThenNamed, so its name
+// appears in StageErrors, timelines, logs and profiler labels. Configure a
+// stage before applying it; the settings must not change afterwards.
+type Stage[A, B any] struct {
+	name    string
+	fn      func(A) (B, error)
+	timeout time.Duration
+	retry   BackoffPolicy
+	sem     *Semaphore
+}
+
+// NewStage creates a stage named name running fn.
+func NewStage[A, B any](name string, fn func(A) (B, error)) *Stage[A, B] {
+	return &Stage[A, B]{name: name, fn: fn}
+}
+
+// WithTimeout gives every application of the stage its own deadline of d, as
+// with Future.WithTimeout, and returns s. The deadline covers all attempts
+// of a retried stage.
+func (s *Stage[A, B]) WithTimeout(d time.Duration) *Stage[A, B] {
+	s.timeout = d
+	return s
+}
+
+// WithRetry makes the stage try fn again, as with Retry, until it succeeds
+// or policy gives up, and returns s.
+func (s *Stage[A, B]) WithRetry(policy BackoffPolicy) *Stage[A, B] {
+	s.retry = policy
+	return s
+}
+
+// WithConcurrency caps how many applications of the stage run fn at once, in
+// every pipeline it is used in, and returns s. While it waits for a slot, a
+// stage on an executor holds its worker, as with Future.Limit.
+func (s *Stage[A, B]) WithConcurrency(n int64) *Stage[A, B] {
+	s.sem = NewSemaphore(n)
+	return s
+}
+
+// Apply chains the stage onto f, returning a future for its output.
+func (s *Stage[A, B]) Apply(f *Future[A]) *Future[B] {
+	// run may start before ThenNamed returns if f has already settled.
+	ready := make(chan struct{})
+	var next *Future[B]
+	next = ThenNamed(f, s.name, func(v A) (B, error) {
+		<-ready
+		if s.sem != nil {
+			if !s.sem.hold(next.settled) {
+				// next settled while waiting, so the outcome is discarded.
+				var zero B
+				return zero, ErrCancelled
+			}
+			defer s.sem.Release(1)
+		}
+		if s.retry == nil {
+			return s.fn(v)
+		}
+		return next.retry(func() (B, error) { return s.fn(v) }, s.retry)
+	})
+	if s.timeout > 0 {
+		next.WithTimeout(s.timeout)
+	}
+	close(ready)
+	return next
+}
+
+// Pipe2 runs src through two stages, returning a future for the output of
+// the last one.
+func Pipe2[A, B, C any](src *Future[A], s1 *Stage[A, B], s2 *Stage[B, C]) *Future[C] {
+	return s2.Apply(s1.Apply(src))
+}
+
+// Pipe3 runs src through three stages, returning a future for the output of
+// the last one.
+func Pipe3[A, B, C, D any](src *Future[A], s1 *Stage[A, B], s2 *Stage[B, C], s3 *Stage[C, D]) *Future[D] {
+	return s3.Apply(s2.Apply(s1.Apply(src)))
+}
+
+// Pipe4 runs src through four stages, returning a future for the output of
+// the last one.
+func Pipe4[A, B, C, D, E any](src *Future[A], s1 *Stage[A, B], s2 *Stage[B, C], s3 *Stage[C, D], s4 *Stage[D, E]) *Future[E] {
+	return s4.Apply(s3.Apply(s2.Apply(s1.Apply(src))))
+}
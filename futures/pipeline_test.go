@@ -0,0 +1,76 @@
+package futures_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipe3RunsTypedNamedStages(t *testing.T) {
+	parse := futures.NewStage("parse", strconv.Atoi)
+	double := futures.NewStage("double", func(n int) (int, error) { return n * 2, nil })
+	format := futures.NewStage("format", func(n int) (string, error) { return fmt.Sprintf("answer=%d", n), nil })
+
+	v, err := futures.Pipe3(futures.Resolved("21"), parse, double, format).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "answer=42", v)
+
+	_, err = futures.Pipe3(futures.Resolved("x"), parse, double, format).Result()
+	var stageErr *futures.StageError
+	assert.ErrorAs(t, err, &stageErr)
+	assert.Equal(t, "parse", stageErr.Name)
+}
+
+func TestStageRetriesAndTimesOut(t *testing.T) {
+	var attempts atomic.Int32
+	flaky := futures.NewStage("flaky", func(n int) (int, error) {
+		if attempts.Add(1) < 3 {
+			return 0, errors.New("flaky")
+		}
+		return n + 1, nil
+	}).WithRetry(futures.ConstantBackoff(time.Millisecond))
+
+	v, err := flaky.Apply(futures.Resolved(1)).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, int32(3), attempts.Load())
+
+	release := make(chan struct{})
+	defer close(release)
+	slow := futures.NewStage("slow", func(n int) (int, error) {
+		<-release
+		return n, nil
+	}).WithTimeout(10 * time.Millisecond)
+	_, err = slow.Apply(futures.Resolved(1)).Result()
+	assert.ErrorIs(t, err, futures.ErrStageTimeout)
+}
+
+func TestStageConcurrencyIsSharedAcrossPipelines(t *testing.T) {
+	var running, peak atomic.Int32
+	work := futures.NewStage("work", func(n int) (int, error) {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return n, nil
+	}).WithConcurrency(2)
+
+	outs := make([]*futures.Future[int], 8)
+	for i := range outs {
+		outs[i] = work.Apply(futures.Resolved(i))
+	}
+	_, err := futures.All(outs...).Result()
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
@@ -0,0 +1,90 @@
+package futures
+
+import "context"
+
+type poolConfig struct {
+	maxWorkers int
+	queueSize  int
+	opts       []ExecutorOption
+}
+
+// WithPool declares a named pool of the executor with its own worker limit
+// and queue, configured by opts. See Pool.
+func WithPool(name string, maxWorkers, queueSize int, opts ...ExecutorOption) ExecutorOption {
+	return func(e *Executor) {
+		if e.poolCfg == nil {
+			e.poolCfg = make(map[string]poolConfig)
+		}
+		e.poolCfg[name] = poolConfig{maxWorkers: maxWorkers, queueSize: queueSize, opts: opts}
+	}
+}
+
+// Pool returns the executor's pool called name, creating it on first use.
+// Pools are executors of their own, with independent workers and queues, so
+// that one class of work (database calls, CPU-bound jobs, ...) piling up
+// cannot starve the others: the bulkhead pattern.
+//
+// A pool declared with WithPool gets the sizes and options given there; any
+// other name gets the sizes of e. Pools use e's middleware and clock unless
+// configured otherwise. Their metrics go to e's collector, or, if that
+// collector implements PoolMetricsCollector, to the one it returns for the
+// pool. Shutting e down shuts its pools down as well.
+func (e *Executor) Pool(name string) *Executor {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if p, ok := e.pools[name]; ok {
+		return p
+	}
+	cfg, ok := e.poolCfg[name]
+	if !ok {
+		cfg = poolConfig{maxWorkers: e.maxWorkers, queueSize: cap(e.queue)}
+	}
+
+	inherit := func(p *Executor) {
+		p.name = name
+		p.middleware = e.middleware
+		p.clock = e.clock
+		p.direct = e.direct
+		p.logger = e.logger
+		p.slowAfter, p.onSlow = e.slowAfter, e.onSlow
+		p.metrics = collectorFor(e)
+		if pc, ok := p.metrics.(PoolMetricsCollector); ok {
+			p.metrics = pc.ForPool(name)
+		}
+	}
+	p := NewExecutor(cfg.maxWorkers, cfg.queueSize, append([]ExecutorOption{inherit}, cfg.opts...)...)
+	if e.closed {
+		p.closed = true
+		p.checkDrainedLocked()
+	}
+	if e.pools == nil {
+		e.pools = make(map[string]*Executor)
+	}
+	e.pools[name] = p
+	return p
+}
+
+// Name returns the name of a pool, or "" for an executor created with
+// NewExecutor.
+func (e *Executor) Name() string {
+	return e.name
+}
+
+// shutdownPools shuts down every pool of e, returning the first error.
+func (e *Executor) shutdownPools(ctx context.Context) error {
+	e.mu.Lock()
+	pools := make([]*Executor, 0, len(e.pools))
+	for _, p := range e.pools {
+		pools = append(pools, p)
+	}
+	e.mu.Unlock()
+
+	var first error
+	for _, p := range pools {
+		if err := p.Shutdown(ctx); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,45 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolsIsolateSlowWork(t *testing.T) {
+	exec := futures.NewExecutor(4, 4, futures.WithPool("db", 1, 4))
+	db := exec.Pool("db")
+	assert.Same(t, db, exec.Pool("db"))
+	assert.Equal(t, "db", db.Name())
+
+	// Saturate the db pool; the cpu pool keeps serving.
+	release := make(chan struct{})
+	blocked := futures.Submit(db, func() (int, error) { <-release; return 1, nil })
+	queued := futures.Submit(db, func() (int, error) { return 2, nil })
+
+	v, err := futures.Submit(exec.Pool("cpu"), func() (int, error) { return 3, nil }).ResultTimeout(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	assert.True(t, queued.Queued())
+
+	close(release)
+	_, err = blocked.Result()
+	assert.NoError(t, err)
+	_, err = queued.Result()
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, db.Workers(), 1)
+}
+
+func TestShutdownClosesPools(t *testing.T) {
+	exec := futures.NewExecutor(1, 1)
+	pool := exec.Pool("io")
+	assert.NoError(t, exec.Shutdown(context.Background()))
+
+	_, err := futures.Submit(pool, func() (int, error) { return 1, nil }).Result()
+	assert.ErrorIs(t, err, futures.ErrExecutorShutdown)
+	_, err = futures.Submit(exec.Pool("late"), func() (int, error) { return 1, nil }).Result()
+	assert.ErrorIs(t, err, futures.ErrExecutorShutdown)
+}
@@ -0,0 +1,88 @@
+package futures
+
+import (
+	"container/heap"
+	"time"
+)
+
+// SubmitPriority is like Submit, but the task is picked up ahead of queued
+// tasks of lower priority. Tasks submitted with Submit have priority 0, and
+// stages chained from a future inherit its priority.
+func SubmitPriority[T any](e *Executor, priority int, task func() (T, error)) *Future[T] {
+	f := newFuture(task, e, options{})
+	f.prio = priority
+	f.Start()
+	return f
+}
+
+// SubmitDeadline is like Submit, but among queued tasks of the same priority
+// the ones with the earliest deadline are picked up first, ahead of tasks
+// without one. The deadline only orders the queue; use WithTimeout to bound
+// how long the task may run.
+func SubmitDeadline[T any](e *Executor, deadline time.Time, task func() (T, error)) *Future[T] {
+	f := newFuture(task, e, options{})
+	f.due = deadline
+	f.Start()
+	return f
+}
+
+// QueueDepths returns the number of queued tasks at each priority.
+func (e *Executor) QueueDepths() map[int]int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	depths := make(map[int]int)
+	for _, t := range e.pending.tasks {
+		depths[t.priority]++
+	}
+	return depths
+}
+
+// taskQueue orders an executor's queued tasks: highest priority first, then
+// earliest deadline, then first submitted.
+type taskQueue struct {
+	tasks []execTask
+	seq   uint64
+}
+
+func (q *taskQueue) push(t execTask) {
+	q.seq++
+	t.seq = q.seq
+	heap.Push((*taskHeap)(q), t)
+}
+
+func (q *taskQueue) pop() execTask {
+	return heap.Pop((*taskHeap)(q)).(execTask)
+}
+
+type taskHeap taskQueue
+
+func (h *taskHeap) Len() int { return len(h.tasks) }
+
+func (h *taskHeap) Less(i, j int) bool {
+	a, b := &h.tasks[i], &h.tasks[j]
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	if !a.deadline.Equal(b.deadline) {
+		switch {
+		case a.deadline.IsZero():
+			return false
+		case b.deadline.IsZero():
+			return true
+		}
+		return a.deadline.Before(b.deadline)
+	}
+	return a.seq < b.seq
+}
+
+func (h *taskHeap) Swap(i, j int) { h.tasks[i], h.tasks[j] = h.tasks[j], h.tasks[i] }
+
+func (h *taskHeap) Push(x any) { h.tasks = append(h.tasks, x.(execTask)) }
+
+func (h *taskHeap) Pop() any {
+	n := len(h.tasks) - 1
+	t := h.tasks[n]
+	h.tasks[n] = execTask{}
+	h.tasks = h.tasks[:n]
+	return t
+}
@@ -0,0 +1,44 @@
+package futures_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutorRunsHigherPriorityFirst(t *testing.T) {
+	exec := futures.NewExecutor(1, 10)
+	release := make(chan struct{})
+	blocker := futures.Submit(exec, func() (string, error) { <-release; return "", nil })
+	assert.Eventually(t, func() bool { return !blocker.Queued() }, time.Second, time.Millisecond)
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func() (string, error) {
+		return func() (string, error) {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return name, nil
+		}
+	}
+	now := time.Now()
+	fs := []*futures.Future[string]{
+		futures.Submit(exec, record("normal")),
+		futures.SubmitDeadline(exec, now.Add(time.Hour), record("due later")),
+		futures.SubmitPriority(exec, 5, record("urgent")),
+		futures.SubmitDeadline(exec, now.Add(time.Minute), record("due soon")),
+		futures.SubmitPriority(exec, -1, record("background")),
+	}
+	assert.Equal(t, map[int]int{0: 3, 5: 1, -1: 1}, exec.QueueDepths())
+
+	close(release)
+	for _, f := range fs {
+		_, err := f.Result()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"urgent", "due soon", "due later", "normal", "background"}, order)
+}
@@ -0,0 +1,76 @@
+package futures
+
+import "slices"
+
+// Progress is an update reported by a task created with NewProgressFuture.
+type Progress struct {
+	Done    int64  // Units of work completed so far
+	Total   int64  // Units of work expected in all; zero if unknown
+	Message string // Optional description of the current step
+}
+
+// Fraction returns the share of the work done, between 0 and 1, or 0 if the
+// total is unknown.
+func (p Progress) Fraction() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	switch r := float64(p.Done) / float64(p.Total); {
+	case r < 0:
+		return 0
+	case r > 1:
+		return 1
+	default:
+		return r
+	}
+}
+
+// NewProgressFuture creates a future whose task can report progress while it
+// runs by calling report, for instance to drive a progress bar or a job
+// dashboard. Consumers subscribe with OnProgress. Reports made once the
+// future has settled are dropped.
+func NewProgressFuture[T any](task func(report func(p Progress)) (T, error), opts ...Option) *Future[T] {
+	return newSelfFuture(buildOptions(opts), func(f *Future[T]) (T, error) {
+		return task(f.report)
+	})
+}
+
+// OnProgress registers a callback receiving the progress updates of a future
+// created with NewProgressFuture. If progress has already been reported, cb
+// first receives the latest update right away. Updates stop once the future
+// settles; other futures never report progress.
+func (f *Future[T]) OnProgress(cb func(Progress)) *Subscription {
+	f.mutex.Lock()
+	if f.state.Load().settled() {
+		f.mutex.Unlock()
+		return &Subscription{}
+	}
+	i := len(f.onProgress)
+	f.onProgress = append(f.onProgress, cb)
+	last, reported := f.progress, f.reported
+	f.mutex.Unlock()
+
+	if reported {
+		f.callback(func() { cb(last) })
+	}
+	return f.subscription(func() { unset(f.onProgress, i) })
+}
+
+// report delivers p to the OnProgress callbacks. Updates reported from
+// several goroutines at once may reach them in any order.
+func (f *Future[T]) report(p Progress) {
+	f.mutex.Lock()
+	if f.state.Load().settled() {
+		f.mutex.Unlock()
+		return
+	}
+	f.progress, f.reported = p, true
+	listeners := slices.Clone(f.onProgress)
+	f.mutex.Unlock()
+
+	for _, cb := range listeners {
+		if cb != nil {
+			f.callback(func() { cb(p) })
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package futures_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressFutureReportsUpdates(t *testing.T) {
+	step := make(chan struct{})
+	f := futures.NewProgressFuture(func(report func(futures.Progress)) (string, error) {
+		report(futures.Progress{Done: 1, Total: 4, Message: "download"})
+		<-step
+		for i := int64(2); i <= 4; i++ {
+			report(futures.Progress{Done: i, Total: 4})
+		}
+		return "ok", nil
+	})
+
+	var mu sync.Mutex
+	var seen []int64
+	first := make(chan struct{})
+	f.OnProgress(func(p futures.Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, p.Done)
+		if p.Done == 1 {
+			close(first)
+		}
+	})
+	f.Start()
+	<-first
+
+	// A late subscriber starts from the latest update.
+	var late futures.Progress
+	f.OnProgress(func(p futures.Progress) {
+		if p.Done == 1 {
+			late = p
+		}
+	})
+	assert.Equal(t, "download", late.Message)
+	assert.Equal(t, 0.25, late.Fraction())
+
+	close(step)
+	v, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", v)
+	mu.Lock()
+	assert.Equal(t, []int64{1, 2, 3, 4}, seen)
+	mu.Unlock()
+
+	var after bool
+	f.OnProgress(func(futures.Progress) { after = true })
+	assert.False(t, after)
+}
+
+func TestProgressFraction(t *testing.T) {
+	assert.Equal(t, 0.0, futures.Progress{Done: 3}.Fraction())
+	assert.Equal(t, 1.0, futures.Progress{Done: 5, Total: 4}.Fraction())
+	assert.Equal(t, 0.5, futures.Progress{Done: 2, Total: 4}.Fraction())
+}
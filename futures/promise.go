@@ -0,0 +1,72 @@
+package futures
+
+import "errors"
+
+// errNilFailure stands in for a nil error passed to Promise.Fail, which would
+// otherwise fulfill the future with a zero value.
+var errNilFailure = errors.New("futures: promise failed with a nil error")
+
+// Promise is the write side of a Future whose outcome is supplied from the
+// outside (a callback, a channel message, a lock hand-off) instead of being
+// computed by a task.
+type Promise[T any] struct {
+	future *Future[T]
+}
+
+// NewPromise creates a Promise with a pending Future.
+func NewPromise[T any]() *Promise[T] {
+	f := NewFuture[T](nil)
+	// There is no task to run; the future settles through the promise.
+	f.started = true
+	return &Promise[T]{future: f}
+}
+
+// Future returns the future controlled by the promise.
+func (p *Promise[T]) Future() *Future[T] {
+	return p.future
+}
+
+// Complete fulfills the future with v. It reports false if the future had
+// already settled (for example because a consumer cancelled it).
+func (p *Promise[T]) Complete(v T) bool {
+	return p.future.complete(v, nil)
+}
+
+// Fail rejects the future with err. It reports false if the future had
+// already settled. A nil err still rejects the future, with a generic error.
+func (p *Promise[T]) Fail(err error) bool {
+	if err == nil {
+		err = errNilFailure
+	}
+	var zero T
+	return p.future.complete(zero, err)
+}
+
+// Resolve settles the future from a (value, error) pair: it fails with err if
+// err is non-nil and completes with v otherwise. It reports false if the
+// future had already settled.
+func (p *Promise[T]) Resolve(v T, err error) bool {
+	if err != nil {
+		return p.Fail(err)
+	}
+	return p.Complete(v)
+}
+
+// Resolved returns a future already fulfilled with v, for APIs that sometimes
+// have an immediate answer (such as a cache hit) but always return a future.
+// Callbacks registered on it still run asynchronously, on a goroutine of
+// their own, one at a time in registration order behind the future's
+// completion queue, so they may not have run yet when registration returns.
+func Resolved[T any](v T) *Future[T] {
+	p := NewPromise[T]()
+	p.Complete(v)
+	return p.Future()
+}
+
+// Failed returns a future already rejected with err. As with Resolved, its
+// callbacks run asynchronously.
+func Failed[T any](err error) *Future[T] {
+	p := NewPromise[T]()
+	p.Fail(err)
+	return p.Future()
+}
@@ -0,0 +1,64 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromiseCompletedFromChannelMessage(t *testing.T) {
+	p := futures.NewPromise[string]()
+	messages := make(chan string)
+	go func() {
+		p.Complete(<-messages)
+	}()
+
+	f := p.Future()
+	assert.Equal(t, futures.Pending, f.State())
+
+	messages <- "pong"
+	v, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", v)
+
+	// Only the first outcome counts.
+	assert.False(t, p.Fail(errors.New("late")))
+	v, _ = f.Result()
+	assert.Equal(t, "pong", v)
+}
+
+func TestPromiseResolveAndFail(t *testing.T) {
+	boom := errors.New("boom")
+	p := futures.NewPromise[int]()
+	assert.True(t, p.Resolve(0, boom))
+	_, err := p.Future().Result()
+	assert.ErrorIs(t, err, boom)
+
+	p = futures.NewPromise[int]()
+	p.Fail(nil)
+	assert.Equal(t, futures.Rejected, p.Future().State())
+}
+
+func TestPromiseFutureCanBeCancelled(t *testing.T) {
+	p := futures.NewPromise[int]()
+	assert.True(t, p.Future().Cancel())
+	assert.False(t, p.Complete(1))
+	assert.Equal(t, futures.Cancelled, p.Future().State())
+}
+
+func TestResolvedAndFailedConstructors(t *testing.T) {
+	hit := futures.Resolved("cached")
+	assert.Equal(t, futures.Fulfilled, hit.State())
+
+	got := make(chan string, 1)
+	hit.OnSuccess(func(v string) { got <- v })
+	assert.Equal(t, "cached", <-got)
+
+	boom := errors.New("boom")
+	miss := futures.Failed[string](boom)
+	assert.Equal(t, futures.Rejected, miss.State())
+	_, err := miss.Result()
+	assert.ErrorIs(t, err, boom)
+}
@@ -0,0 +1,110 @@
+package futures
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket bounding how often futures may start. It
+// refills at a steady rate up to a maximum burst; each start takes one token,
+// and starts that find the bucket empty are queued until a token is due.
+type RateLimiter struct {
+	rate  float64 // tokens per second
+	burst float64
+	clock Clock
+
+	mu     sync.Mutex
+	tokens float64 // negative when tokens have been promised to waiters
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing perSecond starts per second on
+// average and up to burst at once. The bucket starts full. Pass WithClock to
+// measure time on another clock.
+func NewRateLimiter(perSecond float64, burst int, opts ...Option) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	clock := buildOptions(opts).clock
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		clock:  clock,
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+// WithRateLimit makes the workers of an executor wait for a token from l
+// before running each future, including stages chained from them. A worker
+// waiting for a token is not available for other work.
+func WithRateLimit(l *RateLimiter) ExecutorOption {
+	return func(e *Executor) {
+		e.limiter = l
+	}
+}
+
+// Throttle returns a started future for task that runs once l grants it a
+// token. Until then the future is Queued; cancelling it gives the token back.
+func Throttle[T any](l *RateLimiter, task func() (T, error)) *Future[T] {
+	f := NewFuture(task, WithClock(l.clock))
+	f.admit = l.wait
+	f.Start()
+	return f
+}
+
+// Allow takes a token if one is available right now, without waiting.
+func (l *RateLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refillLocked()
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// Wait takes a token, waiting until one is due. If ctx ends first, it
+// returns ctx.Err() and takes nothing.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if !l.wait(ctx.Done) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// wait takes a token, reporting false if the channel returned by cancel is
+// closed before it is due.
+func (l *RateLimiter) wait(cancel func() <-chan struct{}) bool {
+	l.mu.Lock()
+	l.refillLocked()
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(math.Ceil(-l.tokens / l.rate * float64(time.Second)))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return true
+	}
+	select {
+	case <-l.clock.After(delay):
+		return true
+	case <-cancel():
+		l.mu.Lock()
+		l.refillLocked()
+		l.tokens = min(l.tokens+1, l.burst)
+		l.mu.Unlock()
+		return false
+	}
+}
+
+func (l *RateLimiter) refillLocked() {
+	now := l.clock.Now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.burst)
+	l.last = now
+}
@@ -0,0 +1,66 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/futurestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottleQueuesBeyondBurst(t *testing.T) {
+	clock := futurestest.NewClock(time.Now())
+	l := futures.NewRateLimiter(2, 1, futures.WithClock(clock))
+
+	first := futures.Throttle(l, func() (int, error) { return 1, nil })
+	futurestest.RequireResolves(t, first, 1, time.Second)
+
+	second := futures.Throttle(l, func() (int, error) { return 2, nil })
+	clock.BlockUntil(1)
+	assert.True(t, second.Queued())
+	assert.Equal(t, futures.Running, second.State())
+
+	clock.Advance(500 * time.Millisecond)
+	futurestest.RequireResolves(t, second, 2, time.Second)
+	assert.False(t, second.Queued())
+	assert.Equal(t, 500*time.Millisecond, second.QueueWait())
+}
+
+func TestThrottleCancelReturnsToken(t *testing.T) {
+	clock := futurestest.NewClock(time.Now())
+	l := futures.NewRateLimiter(1, 1, futures.WithClock(clock))
+	assert.True(t, l.Allow())
+
+	ran := false
+	f := futures.Throttle(l, func() (int, error) { ran = true; return 0, nil })
+	clock.BlockUntil(1)
+	f.Cancel()
+	_, err := f.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+
+	// The refund happens on the waiting goroutine, shortly after done closes.
+	clock.Advance(time.Second)
+	assert.Eventually(t, l.Allow, time.Second, time.Millisecond)
+	assert.False(t, ran)
+}
+
+func TestExecutorRateLimit(t *testing.T) {
+	clock := futurestest.NewClock(time.Now())
+	l := futures.NewRateLimiter(10, 2, futures.WithClock(clock))
+	exec := futures.NewExecutor(4, 10, futures.WithRateLimit(l), futures.WithExecutorClock(clock))
+
+	var fs []*futures.Future[int]
+	for i := range 3 {
+		fs = append(fs, futures.Submit(exec, func() (int, error) { return i, nil }))
+	}
+	futurestest.RequireResolves(t, fs[0], 0, time.Second)
+	futurestest.RequireResolves(t, fs[1], 1, time.Second)
+	clock.BlockUntil(1)
+	assert.True(t, fs[2].Queued())
+
+	clock.Advance(100 * time.Millisecond)
+	futurestest.RequireResolves(t, fs[2], 2, time.Second)
+	assert.NoError(t, exec.Shutdown(context.Background()))
+}
@@ -0,0 +1,52 @@
+package futures
+
+import (
+	"reflect"
+	"sync"
+)
+
+// futurePools holds a pool of released futures for each result type.
+var futurePools sync.Map // of reflect.Type to *sync.Pool
+
+func poolFor[T any]() *sync.Pool {
+	t := reflect.TypeFor[T]()
+	if p, ok := futurePools.Load(t); ok {
+		return p.(*sync.Pool)
+	}
+	p, _ := futurePools.LoadOrStore(t, &sync.Pool{})
+	return p.(*sync.Pool)
+}
+
+// allocFuture returns a zeroed future, reusing a released one if there is
+// one.
+func allocFuture[T any]() *Future[T] {
+	if f, ok := poolFor[T]().Get().(*Future[T]); ok {
+		return f
+	}
+	return &Future[T]{}
+}
+
+// Release hands f back for reuse by futures created later, saving an
+// allocation per stage in hot loops building short-lived chains:
+//
+//	v, err := stage.Result()
+//	stage.Release()
+//
+// It may only be called once nothing will use f again: the caller and any
+// other holder are done with it, and so is every stage, combinator or waiter
+// built on it. Release reports false and leaves f alone if f has not
+// finished settling, which includes running the continuations of everything
+// built on it, or still has consumers waiting on it; calling it is always
+// optional.
+func (f *Future[T]) Release() bool {
+	f.mutex.Lock()
+	if !f.finished.Load() || f.consumers.Load() > 0 || f.onSettle != nil || f.queue.busy() {
+		f.mutex.Unlock()
+		return false
+	}
+	f.mutex.Unlock()
+
+	*f = Future[T]{}
+	poolFor[T]().Put(f)
+	return true
+}
@@ -0,0 +1,78 @@
+package futures_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseOnlyRecyclesFinishedFutures(t *testing.T) {
+	p := futures.NewPromise[int]()
+	assert.False(t, p.Future().Release(), "pending futures are kept")
+
+	child := futures.Then(p.Future(), func(v int) (int, error) { return v + 1, nil })
+	p.Complete(1)
+	v, err := child.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	// Result may return while complete is still finishing up.
+	assert.Eventually(t, child.Release, time.Second, time.Millisecond)
+	assert.Eventually(t, p.Future().Release, time.Second, time.Millisecond)
+
+	// Futures made afterwards start from a clean slate.
+	for range 10 {
+		f := futures.Resolved(7)
+		assert.Equal(t, futures.Fulfilled, f.State())
+		v, err := f.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, 7, v)
+		f.Release()
+	}
+}
+
+func TestReleaseWaitsForContinuations(t *testing.T) {
+	for range 20 {
+		p := futures.NewPromise[int]()
+		f := p.Future()
+		var early []<-chan futures.Result[int]
+		for range 5000 {
+			early = append(early, f.ToChannel())
+		}
+		var wg sync.WaitGroup
+		late := make(chan (<-chan futures.Result[int]), 20)
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				late <- f.ToChannel()
+			}()
+		}
+		// Complete on another goroutine, so that Result returns as soon as
+		// done closes, while the continuations are still running.
+		go p.Complete(1)
+		v, err := f.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+		wg.Wait()
+
+		// Released while its continuations may still be delivering outcomes,
+		// as in the stage.Result(); stage.Release() pattern.
+		assert.Eventually(t, f.Release, time.Second, time.Microsecond)
+		close(late)
+		for _, ch := range early {
+			assert.Equal(t, 1, (<-ch).Value)
+		}
+		for ch := range late {
+			assert.Equal(t, 1, (<-ch).Value)
+		}
+		// A recycled future, possibly f, must not be disturbed by f's
+		// completion.
+		g := futures.Then(futures.Resolved(2), func(n int) (int, error) { return n, nil })
+		v, err = g.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, v)
+	}
+}
@@ -0,0 +1,111 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+// Client awaits futures published by a Server.
+type Client[T any] struct {
+	base string
+	http *http.Client
+
+	// Wait is how long each request asks the server to hold it until the
+	// future settles. Zero turns long-polling off: the client then polls
+	// every PollInterval instead.
+	Wait time.Duration
+
+	// PollInterval is the pause between requests when Wait is zero.
+	PollInterval time.Duration
+}
+
+// NewClient creates a client for the server mounted at baseURL, sending
+// requests with client, or http.DefaultClient if client is nil. It
+// long-polls for 30 seconds at a time.
+func NewClient[T any](baseURL string, client *http.Client) *Client[T] {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Client[T]{
+		base:         strings.TrimSuffix(baseURL, "/"),
+		http:         client,
+		Wait:         30 * time.Second,
+		PollInterval: time.Second,
+	}
+}
+
+// Await returns a started future settling like the remote future id: with
+// its value, or with a *RemoteError carrying its error. It rejects with
+// ErrNotFound if the server does not know id, and with ctx.Err() if ctx ends
+// first. Cancelling the returned future only stops waiting; use Cancel to
+// cancel the remote future itself.
+func (c *Client[T]) Await(ctx context.Context, id string) *futures.Future[T] {
+	f := futures.NewFutureCtx(ctx, func(ctx context.Context) (T, error) {
+		for {
+			st, err := c.fetch(ctx, http.MethodGet, id, c.Wait)
+			if err != nil {
+				var zero T
+				return zero, err
+			}
+			if st.Settled() {
+				return st.Value, st.Err()
+			}
+			if c.Wait > 0 {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				var zero T
+				return zero, ctx.Err()
+			case <-time.After(c.PollInterval):
+			}
+		}
+	})
+	f.Start()
+	return f
+}
+
+// Get returns the current status of the remote future id without waiting.
+func (c *Client[T]) Get(ctx context.Context, id string) (Status[T], error) {
+	return c.fetch(ctx, http.MethodGet, id, 0)
+}
+
+// Cancel cancels the remote future id and returns its status afterwards.
+func (c *Client[T]) Cancel(ctx context.Context, id string) (Status[T], error) {
+	return c.fetch(ctx, http.MethodDelete, id, 0)
+}
+
+func (c *Client[T]) fetch(ctx context.Context, method, id string, wait time.Duration) (Status[T], error) {
+	u := c.base + "/" + url.PathEscape(id)
+	if wait > 0 {
+		u += "?wait=" + url.QueryEscape(wait.String())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return Status[T]{}, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return Status[T]{}, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return Status[T]{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	case resp.StatusCode != http.StatusOK:
+		return Status[T]{}, fmt.Errorf("remote: unexpected status %s", resp.Status)
+	}
+	var st Status[T]
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+		return Status[T]{}, fmt.Errorf("remote: decoding status: %w", err)
+	}
+	return st, nil
+}
@@ -0,0 +1,78 @@
+// Package remote lets a future created in one process be awaited from
+// another over HTTP, for instance to back a lightweight job API.
+//
+// A Server keeps the futures registered with it under random IDs and reports
+// their status as JSON. Clients either poll it or long-poll, asking the
+// server to hold the request until the future settles:
+//
+//	jobs := remote.NewServer[Report]()
+//	mux.Handle("/jobs/", http.StripPrefix("/jobs/", jobs))
+//	id := jobs.Register(futures.NewFuture(buildReport))
+//
+//	// Elsewhere:
+//	client := remote.NewClient[Report]("http://reports.internal/jobs", nil)
+//	report, err := client.Await(ctx, id).Result()
+//
+// Values travel as JSON, so T must survive a round trip through
+// encoding/json. Errors travel as their message only and are reported to the
+// client as a *RemoteError.
+package remote
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+// ErrNotFound is returned by a Client for IDs the server does not know, or
+// has forgotten.
+var ErrNotFound = errors.New("remote: no such future")
+
+// Status is the wire form of a remote future, as served by a Server.
+type Status[T any] struct {
+	ID    string `json:"id"`
+	State string `json:"state"`           // One of the futures.State names
+	Value T      `json:"value,omitempty"` // Set once the future is Fulfilled
+	Error string `json:"error,omitempty"` // Set once it is Rejected or Cancelled
+}
+
+// Settled reports whether the remote future has settled.
+func (s Status[T]) Settled() bool {
+	switch s.State {
+	case futures.Fulfilled.String(), futures.Rejected.String(), futures.Cancelled.String():
+		return true
+	}
+	return false
+}
+
+// Err returns the error a remote future settled with, or nil.
+func (s Status[T]) Err() error {
+	switch s.State {
+	case futures.Rejected.String():
+		return &RemoteError{ID: s.ID, Message: s.Error}
+	case futures.Cancelled.String():
+		return &RemoteError{ID: s.ID, Message: s.Error, Cancelled: true}
+	}
+	return nil
+}
+
+// RemoteError is the error of a future that failed in another process.
+type RemoteError struct {
+	ID        string // ID of the remote future
+	Message   string // The error's message on the server
+	Cancelled bool   // The remote future was cancelled
+}
+
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("remote: future %s: %s", e.ID, e.Message)
+}
+
+// Unwrap returns futures.ErrCancelled for a cancelled remote future, so that
+// errors.Is tells cancellations apart from failures.
+func (e *RemoteError) Unwrap() error {
+	if e.Cancelled {
+		return futures.ErrCancelled
+	}
+	return nil
+}
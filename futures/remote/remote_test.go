@@ -0,0 +1,81 @@
+package remote_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+type report struct {
+	Rows int `json:"rows"`
+}
+
+func serve(t *testing.T) (*remote.Server[report], *remote.Client[report]) {
+	server := remote.NewServer[report]()
+	mux := http.NewServeMux()
+	mux.Handle("/jobs/", http.StripPrefix("/jobs/", server))
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return server, remote.NewClient[report](ts.URL+"/jobs", ts.Client())
+}
+
+func TestAwaitLongPollsUntilSettled(t *testing.T) {
+	server, client := serve(t)
+	release := make(chan struct{})
+	id := server.Register(futures.NewFuture(func() (report, error) {
+		<-release
+		return report{Rows: 3}, nil
+	}))
+
+	st, err := client.Get(context.Background(), id)
+	assert.NoError(t, err)
+	assert.False(t, st.Settled())
+
+	f := client.Await(context.Background(), id)
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, f.IsDone())
+	close(release)
+
+	v, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, report{Rows: 3}, v)
+}
+
+func TestAwaitPollsAndReportsRemoteErrors(t *testing.T) {
+	server, client := serve(t)
+	client.Wait, client.PollInterval = 0, 5*time.Millisecond
+
+	id := server.Register(futures.Failed[report](errors.New("disk full")))
+	_, err := client.Await(context.Background(), id).Result()
+	var remoteErr *remote.RemoteError
+	assert.ErrorAs(t, err, &remoteErr)
+	assert.Equal(t, "disk full", remoteErr.Message)
+	assert.NotErrorIs(t, err, futures.ErrCancelled)
+
+	_, err = client.Await(context.Background(), "missing").Result()
+	assert.ErrorIs(t, err, remote.ErrNotFound)
+
+	server.Forget(id)
+	_, err = client.Get(context.Background(), id)
+	assert.ErrorIs(t, err, remote.ErrNotFound)
+}
+
+func TestCancelStopsTheRemoteFuture(t *testing.T) {
+	server, client := serve(t)
+	id := server.Register(futures.NewPromise[report]().Future())
+
+	f := client.Await(context.Background(), id)
+	st, err := client.Cancel(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, futures.Cancelled.String(), st.State)
+
+	_, err = f.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+}
@@ -0,0 +1,115 @@
+package remote
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+)
+
+// MaxWait bounds how long a Server holds a long-poll request.
+const MaxWait = time.Minute
+
+// Server publishes futures over HTTP. It answers requests whose path ends in
+// a future's ID:
+//
+//   - GET returns its Status right away, or, with a wait query parameter
+//     such as ?wait=30s, once it settles or the wait (at most MaxWait) is
+//     over, whichever comes first
+//   - DELETE cancels it
+//
+// Unknown IDs get 404 Not Found. Settled futures are kept until forgotten.
+type Server[T any] struct {
+	mu      sync.Mutex
+	futures map[string]*futures.Future[T]
+}
+
+// NewServer creates a server with no futures registered.
+func NewServer[T any]() *Server[T] {
+	return &Server[T]{futures: make(map[string]*futures.Future[T])}
+}
+
+// Register starts f and returns the ID under which clients can await it.
+func (s *Server[T]) Register(f *futures.Future[T]) string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	id := hex.EncodeToString(b[:])
+
+	s.mu.Lock()
+	s.futures[id] = f
+	s.mu.Unlock()
+	f.Start()
+	return id
+}
+
+// Forget removes the future registered as id, so that the server no longer
+// holds on to its result. Clients asking for it get ErrNotFound.
+func (s *Server[T]) Forget(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.futures, id)
+}
+
+// Status returns the current status of the future registered as id.
+func (s *Server[T]) Status(id string) (Status[T], bool) {
+	s.mu.Lock()
+	f, ok := s.futures[id]
+	s.mu.Unlock()
+	if !ok {
+		return Status[T]{}, false
+	}
+	return statusOf(id, f), true
+}
+
+func (s *Server[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := path.Base(r.URL.Path)
+	s.mu.Lock()
+	f, ok := s.futures[id]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, ErrNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodDelete:
+		f.Cancel()
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if wait, err := time.ParseDuration(r.URL.Query().Get("wait")); err == nil && wait > 0 {
+		timer := time.NewTimer(min(wait, MaxWait))
+		defer timer.Stop()
+		select {
+		case <-f.Done():
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(statusOf(id, f))
+}
+
+func statusOf[T any](id string, f *futures.Future[T]) Status[T] {
+	// Read the state first: once it is settled, the outcome is there too.
+	st := Status[T]{ID: id, State: f.State().String()}
+	if v, err, _ := f.TryResult(); st.Settled() {
+		if err != nil {
+			st.Error = err.Error()
+		} else {
+			st.Value = v
+		}
+	}
+	return st
+}
@@ -0,0 +1,126 @@
+package futures
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// StepTiming is the timing of one step in a Report.
+type StepTiming struct {
+	Name      string
+	Offset    time.Duration // when the step started, relative to the run
+	QueueWait time.Duration // between becoming ready and starting
+	Duration  time.Duration
+	Critical  bool // whether the step lies on the critical path
+}
+
+// Report summarises where the time of a finished run went.
+type Report struct {
+	Total        time.Duration
+	Steps        []StepTiming // slowest first
+	CriticalPath []StepTiming // in execution order
+}
+
+// Report computes the critical path and step timings of the run. The critical
+// path is the chain of steps that gated the end of the run: from the step that
+// finished last, it repeatedly follows the dependency that finished last.
+// Steps that never ran are left out.
+func (rec RunRecord) Report() *Report {
+	byName := make(map[string]StepRecord, len(rec.Steps))
+	var origin, end time.Time
+	for _, s := range rec.Steps {
+		if s.Started.IsZero() {
+			continue
+		}
+		byName[s.Name] = s
+		if origin.IsZero() || s.Ready.Before(origin) {
+			origin = s.Ready
+		}
+		if s.Finished.After(end) {
+			end = s.Finished
+		}
+	}
+
+	timing := func(s StepRecord) StepTiming {
+		return StepTiming{
+			Name:      s.Name,
+			Offset:    s.Started.Sub(origin),
+			QueueWait: s.Started.Sub(s.Ready),
+			Duration:  s.Finished.Sub(s.Started),
+		}
+	}
+
+	// Walk the critical path backwards from the last step to finish.
+	critical := make(map[string]bool)
+	var path []StepTiming
+	var last *StepRecord
+	for _, s := range byName {
+		if last == nil || s.Finished.After(last.Finished) {
+			last = &s
+		}
+	}
+	for last != nil {
+		critical[last.Name] = true
+		path = append(path, timing(*last))
+
+		var gate *StepRecord
+		for _, dep := range last.DependsOn {
+			if d, ok := byName[dep]; ok && (gate == nil || d.Finished.After(gate.Finished)) {
+				gate = &d
+			}
+		}
+		last = gate
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	for i := range path {
+		path[i].Critical = true
+	}
+
+	steps := make([]StepTiming, 0, len(byName))
+	for _, s := range rec.Steps {
+		if _, ok := byName[s.Name]; ok {
+			t := timing(s)
+			t.Critical = critical[s.Name]
+			steps = append(steps, t)
+		}
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].Duration > steps[j].Duration })
+
+	return &Report{Total: end.Sub(origin), Steps: steps, CriticalPath: path}
+}
+
+// Slowest returns up to n steps with the longest durations.
+func (r *Report) Slowest(n int) []StepTiming {
+	if n > len(r.Steps) {
+		n = len(r.Steps)
+	}
+	return r.Steps[:n]
+}
+
+// String renders the report as a table, slowest steps first, followed by the
+// critical path.
+func (r *Report) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tSTART\tQUEUE\tDURATION\tCRITICAL")
+	for _, s := range r.Steps {
+		mark := ""
+		if s.Critical {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%s\t%v\t%v\t%v\t%s\n", s.Name, s.Offset, s.QueueWait, s.Duration, mark)
+	}
+	w.Flush()
+
+	names := make([]string, len(r.CriticalPath))
+	for i, s := range r.CriticalPath {
+		names[i] = s.Name
+	}
+	fmt.Fprintf(&b, "critical path: %s (total %v)\n", strings.Join(names, " -> "), r.Total)
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package futures
+
+import "fmt"
+
+// Result is the outcome of a settled future, or of one item of a stream:
+// either a value or an error. It lets outcomes travel through channels and
+// slices as single values.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Settled is the former name of Result.
+//
+// Deprecated: Use Result.
+type Settled[T any] = Result[T]
+
+// Unwrap returns the value and the error, to go back to the usual two
+// return values.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.Value, r.Err
+}
+
+// MustGet returns the value, or panics if r holds an error.
+func (r Result[T]) MustGet() T {
+	if r.Err != nil {
+		panic(fmt.Sprintf("futures: MustGet on failed result: %v", r.Err))
+	}
+	return r.Value
+}
+
+// AggregateResults returns an AggregateError for the failed results in rs,
+// such as the outcomes of AllSettled, or nil if none failed.
+func AggregateResults[T any](rs []Result[T]) error {
+	errs := make([]error, len(rs))
+	for i, r := range rs {
+		errs[i] = r.Err
+	}
+	return aggregate(errs)
+}
+
+// MustResult waits for the future like Result and returns its value, or
+// panics if it failed. It is meant for scripts and tests, where a failure is
+// fatal anyway.
+func (f *Future[T]) MustResult() T {
+	res, err := f.Result()
+	return Result[T]{Value: res, Err: err}.MustGet()
+}
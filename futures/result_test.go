@@ -0,0 +1,33 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultValue(t *testing.T) {
+	boom := errors.New("boom")
+	ok := futures.Result[int]{Value: 3}
+	v, err := ok.Unwrap()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 3, ok.MustGet())
+
+	failed := futures.Result[int]{Err: boom}
+	assert.Panics(t, func() { failed.MustGet() })
+
+	assert.Equal(t, "a", futures.Resolved("a").MustResult())
+	assert.Panics(t, func() { futures.Failed[string](boom).MustResult() })
+}
+
+func TestStreamToChannel(t *testing.T) {
+	boom := errors.New("boom")
+	var got []futures.Result[int]
+	for r := range produce(2, boom).ToChannel() {
+		got = append(got, r)
+	}
+	assert.Equal(t, []futures.Result[int]{{Value: 1}, {Value: 2}, {Err: boom}}, got)
+}
@@ -0,0 +1,117 @@
+package futures
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffPolicy decides whether a failed attempt is retried and after what
+// delay. Policies are stateless and may be shared between retries.
+type BackoffPolicy interface {
+	// Next is called after attempt number attempt (counting from 1) failed
+	// with err. It returns the delay before the next attempt, or false to
+	// give up.
+	Next(attempt int, err error) (time.Duration, bool)
+}
+
+// BackoffFunc adapts a function to the BackoffPolicy interface.
+type BackoffFunc func(attempt int, err error) (time.Duration, bool)
+
+// Next calls f(attempt, err).
+func (f BackoffFunc) Next(attempt int, err error) (time.Duration, bool) {
+	return f(attempt, err)
+}
+
+// ConstantBackoff retries indefinitely, waiting d between attempts. Combine it
+// with MaxAttempts to bound the number of attempts.
+func ConstantBackoff(d time.Duration) BackoffPolicy {
+	return BackoffFunc(func(int, error) (time.Duration, bool) {
+		return d, true
+	})
+}
+
+// ExponentialBackoff retries indefinitely, doubling the delay from base after
+// each attempt up to max.
+func ExponentialBackoff(base, max time.Duration) BackoffPolicy {
+	return BackoffFunc(func(attempt int, _ error) (time.Duration, bool) {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		return min(d, max), true
+	})
+}
+
+// WithJitter randomises the delays of p by up to fraction of their value in
+// either direction, so that many clients do not retry in lockstep.
+func WithJitter(p BackoffPolicy, fraction float64) BackoffPolicy {
+	return BackoffFunc(func(attempt int, err error) (time.Duration, bool) {
+		d, ok := p.Next(attempt, err)
+		if !ok || d <= 0 {
+			return d, ok
+		}
+		spread := float64(d) * fraction
+		return time.Duration(float64(d) + spread*(2*rand.Float64()-1)), true
+	})
+}
+
+// MaxAttempts stops p after n attempts in total.
+func MaxAttempts(p BackoffPolicy, n int) BackoffPolicy {
+	return BackoffFunc(func(attempt int, err error) (time.Duration, bool) {
+		if attempt >= n {
+			return 0, false
+		}
+		return p.Next(attempt, err)
+	})
+}
+
+// RetryIf only lets p retry errors for which retryable returns true.
+func RetryIf(p BackoffPolicy, retryable func(error) bool) BackoffPolicy {
+	return BackoffFunc(func(attempt int, err error) (time.Duration, bool) {
+		if !retryable(err) {
+			return 0, false
+		}
+		return p.Next(attempt, err)
+	})
+}
+
+// Retry returns a started future running task until it succeeds or policy
+// gives up, in which case the future rejects with the last error (wrapped
+// with the number of attempts). Cancelling the future stops further attempts.
+// Under StartLazy the first attempt waits for a consumer instead.
+func Retry[T any](task func() (T, error), policy BackoffPolicy, opts ...Option) *Future[T] {
+	o := buildOptions(opts)
+
+	f := newSelfFuture(o, func(f *Future[T]) (T, error) {
+		return f.retry(task, policy)
+	})
+	if o.start == StartOnDemand {
+		f.Start()
+	}
+	return f
+}
+
+// retry runs task on behalf of f until it succeeds or policy gives up,
+// waiting on f's clock between attempts. It stops early once f settles.
+func (f *Future[T]) retry(task func() (T, error), policy BackoffPolicy) (T, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := task()
+		if err == nil {
+			return res, nil
+		}
+
+		delay, ok := policy.Next(attempt, err)
+		if !ok {
+			return res, fmt.Errorf("futures: giving up after %d attempts: %w", attempt, err)
+		}
+
+		f.log(slog.LevelWarn, "future retrying", slog.Int("attempt", attempt), slog.Duration("delay", delay), slog.Any("error", err))
+		select {
+		case <-f.clock.After(delay):
+		case <-f.settled():
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package futures
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryEntry is a task parked in a RetryStore. Tasks are identified by a kind
+// naming the registered handler and an opaque payload, because closures cannot
+// outlive the process.
+type RetryEntry struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"`
+	Payload    []byte    `json:"payload"`
+	Attempts   int       `json:"attempts"`
+	LastError  string    `json:"last_error"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+}
+
+// RetryStore persists parked tasks. File, SQL or key-value implementations
+// can be plugged in; they must be safe for concurrent use.
+type RetryStore interface {
+	Put(e RetryEntry) error
+	Delete(id string) error
+	List() ([]RetryEntry, error)
+}
+
+// RetryHandler executes a task of a registered kind.
+type RetryHandler func(payload []byte) error
+
+// RetryQueue runs tasks with a number of in-memory attempts and parks those
+// that keep failing in a RetryStore. Resume re-submits parked tasks, for
+// instance after a process restart.
+type RetryQueue struct {
+	store    RetryStore
+	attempts int
+
+	mu       sync.Mutex
+	handlers map[string]RetryHandler
+}
+
+// NewRetryQueue creates a queue trying each task attempts times before
+// parking it in store.
+func NewRetryQueue(store RetryStore, attempts int) *RetryQueue {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return &RetryQueue{
+		store:    store,
+		attempts: attempts,
+		handlers: make(map[string]RetryHandler),
+	}
+}
+
+// Register installs the handler for tasks of the given kind. Handlers must be
+// registered before Resume so that parked tasks can be matched to them.
+func (q *RetryQueue) Register(kind string, h RetryHandler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[kind] = h
+}
+
+// Submit returns a started future running the task. If every in-memory
+// attempt fails, the task is parked and the future rejects with an error
+// matching both ErrParked and the last task error.
+func (q *RetryQueue) Submit(kind string, payload []byte) *Future[struct{}] {
+	entry := RetryEntry{ID: newRetryID(), Kind: kind, Payload: payload}
+	return q.run(entry, false)
+}
+
+// Resume re-submits every parked task. Tasks that now succeed are removed
+// from the store; the others stay parked with their attempt count updated.
+func (q *RetryQueue) Resume() ([]*Future[struct{}], error) {
+	entries, err := q.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	fs := make([]*Future[struct{}], 0, len(entries))
+	for _, e := range entries {
+		fs = append(fs, q.run(e, true))
+	}
+	return fs, nil
+}
+
+func (q *RetryQueue) run(entry RetryEntry, parked bool) *Future[struct{}] {
+	f := NewFuture(func() (struct{}, error) {
+		q.mu.Lock()
+		h, ok := q.handlers[entry.Kind]
+		q.mu.Unlock()
+		if !ok {
+			return struct{}{}, fmt.Errorf("futures: no retry handler registered for kind %q", entry.Kind)
+		}
+
+		var err error
+		for i := 0; i < q.attempts; i++ {
+			entry.Attempts++
+			if err = h(entry.Payload); err == nil {
+				break
+			}
+		}
+
+		if err == nil {
+			if parked {
+				return struct{}{}, q.store.Delete(entry.ID)
+			}
+			return struct{}{}, nil
+		}
+
+		entry.LastError = err.Error()
+		entry.EnqueuedAt = time.Now()
+		if putErr := q.store.Put(entry); putErr != nil {
+			return struct{}{}, fmt.Errorf("%w; parking for retry failed: %w", err, putErr)
+		}
+		return struct{}{}, fmt.Errorf("%w (id %s): %w", ErrParked, entry.ID, err)
+	})
+	f.Start()
+	return f
+}
+
+func newRetryID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// MemoryRetryStore is a RetryStore kept in memory, mainly useful for tests.
+type MemoryRetryStore struct {
+	mu      sync.Mutex
+	entries map[string]RetryEntry
+}
+
+// NewMemoryRetryStore creates an empty in-memory store.
+func NewMemoryRetryStore() *MemoryRetryStore {
+	return &MemoryRetryStore{entries: make(map[string]RetryEntry)}
+}
+
+// Put stores or replaces an entry.
+func (s *MemoryRetryStore) Put(e RetryEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[e.ID] = e
+	return nil
+}
+
+// Delete removes an entry; deleting an unknown entry is not an error.
+func (s *MemoryRetryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+	return nil
+}
+
+// List returns all entries, oldest first.
+func (s *MemoryRetryStore) List() ([]RetryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]RetryEntry, 0, len(s.entries))
+	for _, e := range s.entries {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].EnqueuedAt.Before(out[j].EnqueuedAt) })
+	return out, nil
+}
+
+// FileRetryStore is a RetryStore keeping one JSON file per entry in a
+// directory, so parked tasks survive restarts.
+type FileRetryStore struct {
+	dir string
+}
+
+// NewFileRetryStore creates a store in dir, creating the directory if needed.
+func NewFileRetryStore(dir string) (*FileRetryStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileRetryStore{dir: dir}, nil
+}
+
+// Put stores or replaces an entry.
+func (s *FileRetryStore) Put(e RetryEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.dir, s.path(e.ID), data)
+}
+
+// writeFileAtomic writes data to path through a temporary file in dir, so a
+// crash never leaves a torn file behind.
+func writeFileAtomic(dir, path string, data []byte) error {
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Delete removes an entry; deleting an unknown entry is not an error.
+func (s *FileRetryStore) Delete(id string) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// List returns all entries, oldest first.
+func (s *FileRetryStore) List() ([]RetryEntry, error) {
+	names, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]RetryEntry, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		var e RetryEntry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("futures: decoding %s: %w", name, err)
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].EnqueuedAt.Before(out[j].EnqueuedAt) })
+	return out, nil
+}
+
+func (s *FileRetryStore) path(id string) string {
+	return filepath.Join(s.dir, strings.ReplaceAll(id, string(filepath.Separator), "_")+".json")
+}
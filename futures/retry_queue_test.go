@@ -0,0 +1,52 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryQueueParksAndResumesAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+	outage := errors.New("smtp unavailable")
+
+	store, err := futures.NewFileRetryStore(dir)
+	require.NoError(t, err)
+	q := futures.NewRetryQueue(store, 3)
+
+	var calls int
+	q.Register("email", func([]byte) error {
+		calls++
+		return outage
+	})
+
+	_, err = q.Submit("email", []byte("hello")).Result()
+	assert.ErrorIs(t, err, futures.ErrParked)
+	assert.ErrorIs(t, err, outage)
+	assert.Equal(t, 3, calls)
+
+	// A new process opens the same directory and the outage is over.
+	store, err = futures.NewFileRetryStore(dir)
+	require.NoError(t, err)
+	q = futures.NewRetryQueue(store, 3)
+
+	var delivered []byte
+	q.Register("email", func(payload []byte) error {
+		delivered = payload
+		return nil
+	})
+
+	fs, err := q.Resume()
+	require.NoError(t, err)
+	require.Len(t, fs, 1)
+	_, err = fs[0].Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), delivered)
+
+	left, err := store.List()
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+}
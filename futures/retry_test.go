@@ -0,0 +1,89 @@
+package futures_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	attempts := 0
+	v, err := futures.Retry(func() (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", errors.New("flaky")
+		}
+		return "ok", nil
+	}, futures.ConstantBackoff(time.Millisecond)).Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", v)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryGivesUpWhenPolicyExhausted(t *testing.T) {
+	flaky := errors.New("flaky")
+	attempts := 0
+	_, err := futures.Retry(func() (int, error) {
+		attempts++
+		return 0, flaky
+	}, futures.MaxAttempts(futures.ExponentialBackoff(time.Millisecond, 4*time.Millisecond), 4)).Result()
+
+	assert.ErrorIs(t, err, flaky)
+	assert.Equal(t, 4, attempts)
+}
+
+func TestRetryFollowsStartPolicyAndClock(t *testing.T) {
+	clock := &tickClock{ticks: make(chan time.Time)}
+	var attempts atomic.Int32
+	f := futures.Retry(func() (int, error) {
+		if attempts.Add(1) < 2 {
+			return 0, errors.New("flaky")
+		}
+		return 2, nil
+	}, futures.ConstantBackoff(time.Hour), futures.WithClock(clock), futures.WithStartPolicy(futures.StartLazy))
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), attempts.Load())
+
+	go func() { clock.ticks <- time.Time{} }()
+	v, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestRetryIfSkipsPermanentErrors(t *testing.T) {
+	permanent := errors.New("not found")
+	attempts := 0
+	policy := futures.RetryIf(futures.ConstantBackoff(time.Millisecond), func(err error) bool {
+		return !errors.Is(err, permanent)
+	})
+
+	_, err := futures.Retry(func() (int, error) {
+		attempts++
+		return 0, permanent
+	}, policy).Result()
+	assert.ErrorIs(t, err, permanent)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestBackoffPolicies(t *testing.T) {
+	exp := futures.ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	for attempt, want := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 40 * time.Millisecond, 4: 50 * time.Millisecond} {
+		d, ok := exp.Next(attempt, nil)
+		assert.True(t, ok)
+		assert.Equal(t, want, d)
+	}
+
+	jittered := futures.WithJitter(futures.ConstantBackoff(100*time.Millisecond), 0.5)
+	for range 20 {
+		d, _ := jittered.Next(1, nil)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 150*time.Millisecond)
+	}
+}
@@ -0,0 +1,155 @@
+package futures
+
+import (
+	"context"
+	"sync"
+)
+
+// OverlapPolicy is what a Scheduler does when a run is due while the
+// previous one has not settled yet.
+type OverlapPolicy int
+
+const (
+	OverlapSkip           OverlapPolicy = iota // Drop the new run
+	OverlapQueue                               // Start it once the previous one settles; due runs coalesce
+	OverlapCancelPrevious                      // Cancel the previous run and start the new one
+)
+
+// Scheduler runs a task repeatedly following a Schedule, each run being a
+// future of its own. Runs can be watched as they start through Runs.
+type Scheduler[T any] struct {
+	sched   Schedule
+	task    func(ctx context.Context) (T, error)
+	ctx     context.Context
+	o       options
+	stop    chan struct{}
+	stopped sync.Once
+	sending sync.Mutex // serialises sending runs with closing the stream
+
+	mu     sync.Mutex
+	last   *Future[T]
+	queued bool
+	runs   *Stream[*Future[T]]
+}
+
+// NewScheduler starts running task following sched until ctx ends or Stop is
+// called. Each run's task gets a context derived from ctx, so ending ctx also
+// cancels the run in progress. Pass WithOverlap to choose what happens when
+// runs overlap, and WithClock to drive the schedule from another clock.
+func NewScheduler[T any](ctx context.Context, sched Schedule, task func(ctx context.Context) (T, error), opts ...Option) *Scheduler[T] {
+	s := &Scheduler[T]{
+		sched: sched,
+		task:  task,
+		ctx:   ctx,
+		o:     buildOptions(opts),
+		stop:  make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+// Runs returns a stream receiving the future of each run as it starts. The
+// stream is closed once the scheduler stops. A consumer that falls behind
+// delays the runs after it, so it should keep reading or stop the stream.
+func (s *Scheduler[T]) Runs() *Stream[*Future[T]] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.runs == nil {
+		s.runs = NewStream[*Future[T]](0)
+	}
+	return s.runs
+}
+
+// Last returns the future of the most recent run, or nil before the first.
+func (s *Scheduler[T]) Last() *Future[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// Stop stops scheduling further runs. A run in progress is left to finish.
+func (s *Scheduler[T]) Stop() {
+	s.stopped.Do(func() { close(s.stop) })
+}
+
+func (s *Scheduler[T]) loop() {
+	defer func() {
+		s.Stop()
+		s.sending.Lock()
+		defer s.sending.Unlock()
+		s.mu.Lock()
+		runs := s.runs
+		s.mu.Unlock()
+		if runs != nil {
+			runs.Close(nil)
+		}
+	}()
+
+	now := s.o.clock.Now()
+	for {
+		next := s.sched.Next(now)
+		if next.IsZero() {
+			return
+		}
+		select {
+		case <-s.o.clock.After(next.Sub(now)):
+		case <-s.stop:
+			return
+		case <-s.ctx.Done():
+			return
+		}
+		now = next
+		s.due()
+	}
+}
+
+// due handles a run falling due under the overlap policy.
+func (s *Scheduler[T]) due() {
+	s.mu.Lock()
+	prev := s.last
+	if prev == nil || prev.IsDone() {
+		s.mu.Unlock()
+		s.start()
+		return
+	}
+	switch s.o.overlap {
+	case OverlapQueue:
+		if !s.queued {
+			s.queued = true
+			prev.whenSettled(func() {
+				s.mu.Lock()
+				s.queued = false
+				s.mu.Unlock()
+				s.start()
+			})
+		}
+		s.mu.Unlock()
+	case OverlapCancelPrevious:
+		s.mu.Unlock()
+		prev.Cancel()
+		s.start()
+	default:
+		s.mu.Unlock()
+	}
+}
+
+func (s *Scheduler[T]) start() {
+	s.sending.Lock()
+	defer s.sending.Unlock()
+	select {
+	case <-s.stop:
+		return
+	default:
+	}
+
+	f := NewFutureCtx(s.ctx, s.task)
+	s.mu.Lock()
+	s.last = f
+	runs := s.runs
+	s.mu.Unlock()
+
+	f.Start()
+	if runs != nil {
+		runs.Send(f)
+	}
+}
@@ -0,0 +1,95 @@
+package futures_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/futurestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerRunsEveryInterval(t *testing.T) {
+	clock := futurestest.NewClock(time.Now())
+	var n atomic.Int32
+	s := futures.NewScheduler(context.Background(), futures.Every(time.Minute), func(context.Context) (int32, error) {
+		return n.Add(1), nil
+	}, futures.WithClock(clock))
+	runs := s.Runs()
+
+	for want := int32(1); want <= 3; want++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		run, err := runs.Recv(context.Background())
+		assert.NoError(t, err)
+		futurestest.RequireResolves(t, run, want, time.Second)
+	}
+
+	s.Stop()
+	_, err := runs.Recv(context.Background())
+	assert.Error(t, err)
+}
+
+func TestSchedulerOverlapPolicies(t *testing.T) {
+	tests := []struct {
+		policy    futures.OverlapPolicy
+		wantRuns  int32
+		cancelled bool
+	}{
+		{futures.OverlapSkip, 1, false},
+		{futures.OverlapQueue, 2, false},
+		{futures.OverlapCancelPrevious, 2, true},
+	}
+	for _, tt := range tests {
+		clock := futurestest.NewClock(time.Now())
+		release := make(chan struct{})
+		var started atomic.Int32
+		s := futures.NewScheduler(context.Background(), futures.Every(time.Second), func(ctx context.Context) (int, error) {
+			started.Add(1)
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			return 0, nil
+		}, futures.WithClock(clock), futures.WithOverlap(tt.policy))
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+		assert.Eventually(t, func() bool { return started.Load() == 1 }, time.Second, time.Millisecond)
+		first := s.Last()
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+		clock.BlockUntil(1) // the second run has been handled
+		assert.Equal(t, tt.cancelled, first.IsCancelled(), "policy %d", tt.policy)
+
+		close(release)
+		assert.Eventually(t, func() bool { return started.Load() == tt.wantRuns }, time.Second, time.Millisecond, "policy %d", tt.policy)
+		s.Stop()
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	at := func(s string) time.Time {
+		v, err := time.Parse("2006-01-02 15:04", s)
+		assert.NoError(t, err)
+		return v
+	}
+	tests := []struct{ spec, from, want string }{
+		{"*/15 * * * *", "2024-03-01 10:07", "2024-03-01 10:15"},
+		{"0 9 * * 1-5", "2024-03-01 09:00", "2024-03-04 09:00"}, // Friday to Monday
+		{"30 2 1 * *", "2024-01-15 00:00", "2024-02-01 02:30"},
+		{"0 0 29 2 *", "2024-03-01 00:00", "2028-02-29 00:00"},
+	}
+	for _, tt := range tests {
+		s := futures.MustCron(tt.spec)
+		assert.Equal(t, at(tt.want), s.Next(at(tt.from)), tt.spec)
+	}
+
+	for _, bad := range []string{"* * *", "60 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := futures.Cron(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,101 @@
+package futures
+
+import (
+	"context"
+	"sync"
+)
+
+// Scope ties the lifetime of a group of futures together, in the manner of
+// errgroup: the first failure cancels every other future of the scope, and
+// Wait returns once all of them have settled.
+//
+//	s := futures.NewScope(ctx)
+//	user := futures.Spawn(s, fetchUser)
+//	orders := futures.Spawn(s, fetchOrders)
+//	if err := s.Wait(); err != nil {
+//		return err
+//	}
+type Scope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	err     error
+	cancels []func() bool
+}
+
+// NewScope creates a scope whose futures run with a context derived from ctx.
+func NewScope(ctx context.Context) *Scope {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Scope{ctx: ctx, cancel: cancel}
+}
+
+// Spawn starts task as a future of s. Tasks receive a context that is
+// cancelled when the scope is; they should watch it to stop early.
+func Spawn[T any](s *Scope, task func(ctx context.Context) (T, error)) *Future[T] {
+	f := NewFutureCtx(s.ctx, task)
+
+	s.wg.Add(1)
+	s.mu.Lock()
+	s.cancels = append(s.cancels, f.Cancel)
+	s.mu.Unlock()
+
+	f.whenSettled(func() {
+		defer s.wg.Done()
+		if _, err := f.outcome(); err != nil {
+			s.fail(err)
+		}
+	})
+	f.Start()
+	return f
+}
+
+// Go starts a task without a result as a future of s.
+func (s *Scope) Go(task func(ctx context.Context) error) *Future[struct{}] {
+	return Spawn(s, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, task(ctx)
+	})
+}
+
+// Wait blocks until every future of the scope has settled and returns the
+// first failure, if any. Futures cancelled because of that failure are not
+// reported.
+func (s *Scope) Wait() error {
+	s.wg.Wait()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Cancel cancels the scope's context and every future of the scope that has
+// not settled yet.
+func (s *Scope) Cancel() {
+	s.cancel()
+	s.mu.Lock()
+	cancels := s.cancels
+	s.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
+
+// Context returns the context handed to the scope's tasks.
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// fail records err as the scope's failure if it is the first one, and then
+// cancels the remaining futures.
+func (s *Scope) fail(err error) {
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.err = err
+	s.mu.Unlock()
+
+	s.Cancel()
+}
@@ -0,0 +1,36 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeFirstFailureCancelsSiblings(t *testing.T) {
+	s := futures.NewScope(context.Background())
+	boom := errors.New("boom")
+
+	slow := futures.Spawn(s, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	s.Go(func(context.Context) error { return boom })
+
+	assert.ErrorIs(t, s.Wait(), boom)
+	assert.Equal(t, futures.Cancelled, slow.State())
+}
+
+func TestScopeWaitsForAll(t *testing.T) {
+	s := futures.NewScope(context.Background())
+	a := futures.Spawn(s, func(context.Context) (string, error) { return "a", nil })
+	b := futures.Spawn(s, func(context.Context) (int, error) { return 2, nil })
+
+	assert.NoError(t, s.Wait())
+	va, _, _ := a.TryResult()
+	vb, _, _ := b.TryResult()
+	assert.Equal(t, "a", va)
+	assert.Equal(t, 2, vb)
+}
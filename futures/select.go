@@ -0,0 +1,54 @@
+package futures
+
+import (
+	"context"
+	"reflect"
+)
+
+// SelectCase is one case of Select, binding a future to a handler. Build it
+// with Case.
+type SelectCase struct {
+	start  func()
+	done   func() <-chan struct{}
+	handle func()
+}
+
+// Case returns a SelectCase for f, whose handler receives f's outcome.
+func Case[T any](f *Future[T], handler func(v T, err error)) SelectCase {
+	return SelectCase{
+		start: f.Start,
+		done:  f.Done,
+		handle: func() {
+			handler(f.outcome())
+		},
+	}
+}
+
+// Select works like a select statement over futures of any types. It starts
+// the futures of cases, waits until one of them settles and runs that case's
+// handler, returning its index:
+//
+//	_, err := futures.Select(ctx,
+//		futures.Case(user, func(u User, err error) { ... }),
+//		futures.Case(quota, func(q int, err error) { ... }),
+//	)
+//
+// If several futures have settled, one of them is chosen at random. If ctx
+// ends first, Select returns -1 and ctx.Err() without running any handler.
+// The other futures are left running, so Select can be called again with the
+// remaining cases.
+func Select(ctx context.Context, cases ...SelectCase) (int, error) {
+	chans := make([]reflect.SelectCase, len(cases)+1)
+	chans[0] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+	for i, c := range cases {
+		c.start()
+		chans[i+1] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.done())}
+	}
+
+	chosen, _, _ := reflect.Select(chans)
+	if chosen == 0 {
+		return -1, ctx.Err()
+	}
+	cases[chosen-1].handle()
+	return chosen - 1, nil
+}
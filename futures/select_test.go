@@ -0,0 +1,32 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectRunsTheFirstSettledCase(t *testing.T) {
+	name := after(time.Second, "ada", nil)
+	quota := after(0, 0, errors.New("over quota"))
+
+	var gotErr error
+	i, err := futures.Select(context.Background(),
+		futures.Case(name, func(string, error) { t.Error("slow case must not run") }),
+		futures.Case(quota, func(_ int, err error) { gotErr = err }),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+	assert.EqualError(t, gotErr, "over quota")
+	assert.False(t, name.IsDone())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	i, err = futures.Select(ctx, futures.Case(name, func(string, error) {}))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, -1, i)
+}
@@ -0,0 +1,194 @@
+package futures
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Semaphore is a weighted semaphore whose Acquire returns a future, so that
+// admission control can be placed in a chain like any other step. Waiters are
+// served in FIFO order: a large request at the head of the queue is not
+// starved by smaller ones arriving after it.
+type Semaphore struct {
+	size    int64
+	cur     int64
+	mu      sync.Mutex
+	waiters list.List // of *semWaiter
+}
+
+type semWaiter struct {
+	n       int64
+	promise *Promise[struct{}]
+}
+
+// NewSemaphore creates a semaphore with the given total weight.
+func NewSemaphore(n int64) *Semaphore {
+	return &Semaphore{size: n}
+}
+
+// Acquire returns a future that resolves once weight n has been acquired.
+// The future rejects with ctx.Err() if ctx ends first, and cancelling it
+// withdraws the request. Once it has resolved the weight must be returned
+// with Release.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) *Future[struct{}] {
+	p := NewPromise[struct{}]()
+
+	if n > s.size {
+		p.Fail(ErrWeightTooLarge)
+		return p.Future()
+	}
+	if err := ctx.Err(); err != nil {
+		p.Fail(err)
+		return p.Future()
+	}
+
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		p.Complete(struct{}{})
+		return p.Future()
+	}
+	elem := s.waiters.PushBack(&semWaiter{n: n, promise: p})
+	s.mu.Unlock()
+
+	stop := context.AfterFunc(ctx, func() {
+		p.Fail(ctx.Err())
+	})
+	p.Future().OnSuccess(func(struct{}) {
+		stop()
+	})
+	// A waiter that fails before being granted gives up its place, which may
+	// unblock the waiters queued behind it.
+	p.Future().OnFailure(func(error) {
+		s.mu.Lock()
+		front := s.waiters.Front() == elem
+		s.waiters.Remove(elem)
+		var granted []*semWaiter
+		if front {
+			granted = s.grantLocked()
+		}
+		s.mu.Unlock()
+		s.deliver(granted)
+	})
+	return p.Future()
+}
+
+// TryAcquire acquires weight n without waiting, reporting whether it
+// succeeded.
+func (s *Semaphore) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// Release returns weight n to the semaphore. It panics if more weight is
+// released than is held.
+func (s *Semaphore) Release(n int64) {
+	s.mu.Lock()
+	s.cur -= n
+	if s.cur < 0 {
+		s.mu.Unlock()
+		panic("futures: semaphore released more than held")
+	}
+	granted := s.grantLocked()
+	s.mu.Unlock()
+	s.deliver(granted)
+}
+
+// grantLocked reserves weight for as many queued waiters as fit, in order.
+func (s *Semaphore) grantLocked() []*semWaiter {
+	var granted []*semWaiter
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return granted
+		}
+		w := front.Value.(*semWaiter)
+		if s.size-s.cur < w.n {
+			return granted
+		}
+		s.cur += w.n
+		s.waiters.Remove(front)
+		granted = append(granted, w)
+	}
+}
+
+// deliver resolves granted waiters outside the lock. A waiter that settled in
+// the meantime hands its reserved weight back.
+func (s *Semaphore) deliver(granted []*semWaiter) {
+	for _, w := range granted {
+		if !w.promise.Complete(struct{}{}) {
+			s.Release(w.n)
+		}
+	}
+}
+
+// Limit makes f wait for a unit of sem's weight before doing its own work and
+// release it once f settles, and returns f:
+//
+//	saved := futures.Then(parsed, store).Limit(dbSlots)
+//
+// Sharing one semaphore between stages caps how many of them run at once
+// across every chain, whatever the size of the executors they run on. While
+// it waits, a stage on an executor holds its worker, as with a rate limit.
+// Limit must be applied before f starts its work; a stage chained from a
+// future that has already settled may start right away.
+func (f *Future[T]) Limit(sem *Semaphore) *Future[T] {
+	// 0 until the weight is held, 1 while it is, 2 once f has settled.
+	var held atomic.Int32
+	f.mutex.Lock()
+	if f.state.Load().settled() || !f.execStart.IsZero() {
+		f.mutex.Unlock()
+		return f
+	}
+	prev := f.admit
+	f.admit = func(cancel func() <-chan struct{}) bool {
+		if prev != nil && !prev(cancel) {
+			return false
+		}
+		if !sem.hold(cancel) {
+			return false
+		}
+		if !held.CompareAndSwap(0, 1) {
+			// f settled while waiting.
+			sem.Release(1)
+			return false
+		}
+		return true
+	}
+	f.mutex.Unlock()
+
+	f.whenSettled(func() {
+		if held.Swap(2) == 1 {
+			sem.Release(1)
+		}
+	})
+	return f
+}
+
+// hold acquires a unit of weight, reporting false if the channel returned by
+// cancel was closed first.
+func (s *Semaphore) hold(cancel func() <-chan struct{}) bool {
+	if s.TryAcquire(1) {
+		return true
+	}
+	acq := s.Acquire(context.Background(), 1)
+	select {
+	case <-acq.Done():
+		return acq.IsSuccess()
+	case <-cancel():
+		if !acq.Cancel() && acq.IsSuccess() {
+			// Granted just as we gave up.
+			s.Release(1)
+		}
+		return false
+	}
+}
@@ -0,0 +1,99 @@
+package futures_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemaphoreQueuesInOrder(t *testing.T) {
+	sem := futures.NewSemaphore(3)
+	ctx := context.Background()
+
+	assert.True(t, sem.TryAcquire(2))
+	big := sem.Acquire(ctx, 3)
+	small := sem.Acquire(ctx, 1)
+
+	// The small request fits but must wait behind the large one.
+	assert.Equal(t, futures.Pending, small.State())
+
+	sem.Release(2)
+	_, err := big.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, futures.Pending, small.State())
+
+	sem.Release(3)
+	_, err = small.Result()
+	assert.NoError(t, err)
+}
+
+func TestSemaphoreAcquireHonoursDeadline(t *testing.T) {
+	sem := futures.NewSemaphore(1)
+	assert.True(t, sem.TryAcquire(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := sem.Acquire(ctx, 1).Result()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The expired waiter must not hold on to any weight.
+	sem.Release(1)
+	assert.True(t, sem.TryAcquire(1))
+}
+
+func TestSemaphoreRejectsOversizedRequest(t *testing.T) {
+	sem := futures.NewSemaphore(2)
+	_, err := sem.Acquire(context.Background(), 5).Result()
+	assert.ErrorIs(t, err, futures.ErrWeightTooLarge)
+}
+
+func TestLimitCapsConcurrentStages(t *testing.T) {
+	sem := futures.NewSemaphore(2)
+	release := make(chan struct{})
+	var running, peak atomic.Int32
+	stage := func(v int) (int, error) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+		return v, nil
+	}
+
+	gate := futures.NewPromise[int]()
+	var stages []*futures.Future[int]
+	for range 5 {
+		stages = append(stages, futures.Then(gate.Future(), stage).Limit(sem))
+	}
+	gate.Complete(1)
+
+	assert.Eventually(t, func() bool { return running.Load() == 2 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(2), running.Load())
+	close(release)
+	_, err := futures.All(stages...).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), peak.Load())
+	assert.True(t, sem.TryAcquire(2), "every stage releases its weight")
+}
+
+func TestLimitReleasesWaiterOnCancel(t *testing.T) {
+	sem := futures.NewSemaphore(1)
+	assert.True(t, sem.TryAcquire(1))
+	gate := futures.NewPromise[int]()
+	waiting := futures.Then(gate.Future(), func(v int) (int, error) { return v, nil }).Limit(sem)
+	gate.Complete(1)
+
+	waiting.Cancel()
+	sem.Release(1)
+	assert.Eventually(t, func() bool { return sem.TryAcquire(1) }, time.Second, time.Millisecond)
+}
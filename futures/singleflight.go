@@ -0,0 +1,86 @@
+package futures
+
+import (
+	"sync"
+	"time"
+)
+
+// SingleFlight deduplicates keyed computations. Concurrent calls to Do for
+// the same key share one future, and a successful result keeps being served
+// until it expires or is forgotten. Failures are not cached: once a future
+// rejects or is cancelled, the next call for its key runs the task again.
+//
+// Callers share the returned future, so cancelling it cancels it for all of
+// them.
+type SingleFlight[K comparable, T any] struct {
+	ttl   time.Duration
+	clock Clock
+	token *CancelToken
+
+	mu      sync.Mutex
+	entries map[K]*flight[T]
+}
+
+type flight[T any] struct {
+	future  *Future[T]
+	expires time.Time // Zero while in flight, or if results never expire
+}
+
+// NewSingleFlight creates an empty SingleFlight. Pass WithTTL to expire
+// cached results; by default they are kept until Forget is called.
+func NewSingleFlight[K comparable, T any](opts ...Option) *SingleFlight[K, T] {
+	o := buildOptions(opts)
+	return &SingleFlight[K, T]{
+		ttl:     o.ttl,
+		clock:   o.clock,
+		token:   o.token,
+		entries: make(map[K]*flight[T]),
+	}
+}
+
+// Do returns the in-flight or cached future for key, or starts task and
+// returns its future if there is none.
+func (s *SingleFlight[K, T]) Do(key K, task func() (T, error)) *Future[T] {
+	s.mu.Lock()
+	if e, ok := s.entries[key]; ok && e.valid(s.clock.Now()) {
+		s.mu.Unlock()
+		return e.future
+	}
+
+	e := &flight[T]{future: NewFuture(task, WithCancelToken(s.token))}
+	s.entries[key] = e
+	s.mu.Unlock()
+
+	e.future.OnComplete(func(_ T, err error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.entries[key] != e {
+			// Forgotten while in flight
+			return
+		}
+		if err != nil {
+			delete(s.entries, key)
+		} else if s.ttl > 0 {
+			e.expires = s.clock.Now().Add(s.ttl)
+		}
+	})
+	e.future.Start()
+	return e.future
+}
+
+// Forget drops the result cached for key, so the next Do runs its task again.
+// Callers already holding an in-flight future still receive its result.
+func (s *SingleFlight[K, T]) Forget(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+// valid reports whether the entry may still be handed out at now.
+func (e *flight[T]) valid(now time.Time) bool {
+	switch e.future.State() {
+	case Rejected, Cancelled:
+		return false
+	}
+	return e.expires.IsZero() || now.Before(e.expires)
+}
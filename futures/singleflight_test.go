@@ -0,0 +1,73 @@
+package futures_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+// nowClock is a Clock whose current time only moves when the test sets it.
+type nowClock struct {
+	now atomic.Pointer[time.Time]
+}
+
+func (c *nowClock) set(t time.Time) { c.now.Store(&t) }
+
+func (c *nowClock) Now() time.Time { return *c.now.Load() }
+
+func (c *nowClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (c *nowClock) AfterFunc(d time.Duration, f func()) futures.Timer {
+	return time.AfterFunc(d, f)
+}
+
+func TestSingleFlightSharesAndCaches(t *testing.T) {
+	clock := &nowClock{}
+	clock.set(time.Unix(0, 0))
+	sf := futures.NewSingleFlight[string, int](futures.WithTTL(time.Minute), futures.WithClock(clock))
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	task := func() (int, error) {
+		<-release
+		return int(calls.Add(1)), nil
+	}
+
+	first := sf.Do("k", task)
+	second := sf.Do("k", task)
+	assert.Same(t, first, second)
+	close(release)
+
+	v, err := second.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	// Still cached within the TTL.
+	v, _ = sf.Do("k", task).Result()
+	assert.Equal(t, 1, v)
+
+	// Expired entries are recomputed.
+	clock.set(time.Unix(0, 0).Add(time.Minute))
+	v, _ = sf.Do("k", task).Result()
+	assert.Equal(t, 2, v)
+
+	sf.Forget("k")
+	v, _ = sf.Do("k", task).Result()
+	assert.Equal(t, 3, v)
+}
+
+func TestSingleFlightRetriesAfterFailure(t *testing.T) {
+	sf := futures.NewSingleFlight[int, string]()
+	boom := errors.New("boom")
+
+	_, err := sf.Do(1, func() (string, error) { return "", boom }).Result()
+	assert.ErrorIs(t, err, boom)
+
+	v, err := sf.Do(1, func() (string, error) { return "ok", nil }).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", v)
+}
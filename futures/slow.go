@@ -0,0 +1,66 @@
+package futures
+
+import "time"
+
+// SlowTask describes a task reported to the handler given to OnSlowTask.
+type SlowTask struct {
+	Stage     string        // Stage label, as in timeline recordings
+	Future    string        // Name given with NewFutureNamed, if any
+	Pool      string        // Executor pool, empty for the executor itself
+	QueueWait time.Duration // How long the task waited for a worker
+	Running   time.Duration // How long it had been running when reported
+}
+
+// OnSlowTask makes the executor call fn for every task, including stages
+// chained from its futures, still running d after its work began. Each task
+// is reported at most once, while it is still running, which helps tell
+// stuck or slow work apart from a saturated executor: compare the report's
+// Running with its QueueWait, or a future's Duration with its QueueWait.
+// fn runs on a timer goroutine and should return quickly. Pools inherit the
+// handler unless configured with their own.
+func OnSlowTask(d time.Duration, fn func(SlowTask)) ExecutorOption {
+	return func(e *Executor) {
+		e.slowAfter, e.onSlow = d, fn
+	}
+}
+
+// armSlowLocked starts the timer reporting f to its executor's OnSlowTask
+// handler, measured from f.execStart.
+func (f *Future[T]) armSlowLocked(queueWait time.Duration) {
+	e := f.executor
+	f.slow = f.clock.AfterFunc(e.slowAfter, func() {
+		f.mutex.Lock()
+		if f.state.Load().settled() {
+			f.mutex.Unlock()
+			return
+		}
+		info := SlowTask{
+			Stage:     f.label(),
+			Future:    f.pipeline,
+			Pool:      e.name,
+			QueueWait: queueWait,
+			Running:   f.clock.Now().Sub(f.execStart),
+		}
+		f.mutex.Unlock()
+		e.onSlow(info)
+	})
+}
+
+// OnSlow calls fn once if f has not settled d after OnSlow is called. Unlike
+// a timeout it leaves f running, so that a caller still waiting for the
+// result can log, record metrics or start a hedged request; fn may well race
+// with f settling. d is measured on f's clock, and fn runs on a timer
+// goroutine, so it should return quickly. Unsubscribing before then, or f
+// settling, stops the call.
+func (f *Future[T]) OnSlow(d time.Duration, fn func()) *Subscription {
+	if f.IsDone() {
+		return &Subscription{}
+	}
+	t := f.clock.AfterFunc(d, func() {
+		if !f.IsDone() {
+			fn()
+		}
+	})
+	f.whenSettled(func() { t.Stop() })
+	return &Subscription{unsubscribe: func() { t.Stop() }}
+}
@@ -0,0 +1,72 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnSlowTaskReportsStuckTasksOnce(t *testing.T) {
+	reports := make(chan futures.SlowTask, 4)
+	e := futures.NewExecutor(2, 2,
+		futures.OnSlowTask(20*time.Millisecond, func(info futures.SlowTask) { reports <- info }),
+		futures.WithPool("io", 1, 1))
+	defer e.Shutdown(context.Background())
+
+	release := make(chan struct{})
+	stuck := futures.Submit(e.Pool("io"), func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	fast := futures.Submit(e, func() (int, error) { return 2, nil })
+	_, err := fast.Result()
+	assert.NoError(t, err)
+
+	info := <-reports
+	assert.Equal(t, "stage 0", info.Stage)
+	assert.Equal(t, "io", info.Pool)
+	assert.GreaterOrEqual(t, info.Running, 20*time.Millisecond)
+
+	close(release)
+	_, err = stuck.Result()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, stuck.Duration(), 20*time.Millisecond)
+	assert.Less(t, stuck.QueueWait(), 20*time.Millisecond)
+	assert.Equal(t, stuck.QueueWait(), stuck.QueueLatency())
+	assert.Equal(t, stuck.Duration(), stuck.ExecLatency())
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, reports)
+}
+
+func TestOnSlowFiresWithoutCancelling(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.NewFuture(func() (int, error) {
+		<-release
+		return 7, nil
+	})
+	f.Start()
+	slow := make(chan struct{}, 1)
+	f.OnSlow(10*time.Millisecond, func() { slow <- struct{}{} })
+	unsubscribed := f.OnSlow(10*time.Millisecond, func() { t.Error("unsubscribed OnSlow ran") })
+	unsubscribed.Unsubscribe()
+
+	<-slow
+	assert.False(t, f.IsDone())
+	close(release)
+	v, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 7, v)
+
+	// Fast futures never report.
+	fast := futures.Resolved(1)
+	fast.OnSlow(time.Millisecond, func() { t.Error("OnSlow ran for a settled future") })
+	quick := futures.NewFuture(func() (int, error) { return 2, nil })
+	quick.OnSlow(20*time.Millisecond, func() { t.Error("OnSlow ran after settling") })
+	quick.Start()
+	_, _ = quick.Result()
+	time.Sleep(40 * time.Millisecond)
+}
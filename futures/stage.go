@@ -0,0 +1,85 @@
+package futures
+
+import (
+	"fmt"
+	"time"
+)
+
+// ThenNamed is Then for a stage with a name, which appears in its StageError
+// and in timeline recordings:
+//
+//	doc := futures.ThenNamed(body, "parse", parseDocument)
+func ThenNamed[T, U any](f *Future[T], name string, fn func(T) (U, error)) *Future[U] {
+	return chainNamed(f, name, func(result T, err error) (U, error) {
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return fn(result)
+	})
+}
+
+// WithTimeout gives this stage of a chain its own deadline and returns f:
+//
+//	parsed := futures.Then(fetched, parse).WithTimeout(time.Second)
+//
+// The deadline counts from when the stage starts its own work, not from when
+// its parent started, so one slow stage cannot stall the whole chain. If it
+// expires, the stage rejects with an error wrapping ErrStageTimeout and
+// stages chained from it fail in turn. As with Cancel, the stage's task is
+// only interrupted if it watches its context.
+func (f *Future[T]) WithTimeout(d time.Duration) *Future[T] {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.state.Load().settled() {
+		return f
+	}
+	f.timeout = d
+	if !f.execStart.IsZero() {
+		// Already running: count from when it started.
+		f.armTimeoutLocked()
+	}
+	return f
+}
+
+// WithDeadline makes f reject with an error wrapping ErrDeadlineExceeded if
+// it has not settled by t, and returns f:
+//
+//	report := futures.Then(rows, render).WithDeadline(requestDeadline)
+//
+// Unlike WithTimeout, the deadline is a point in time that applies whether or
+// not f has started, and unlike ResultTimeout, which only stops one caller
+// waiting, it settles f itself: the task's context is cancelled and inputs no
+// other future needs are cancelled, as for a stage timeout.
+func (f *Future[T]) WithDeadline(t time.Time) *Future[T] {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.state.Load().settled() {
+		return f
+	}
+	if f.expiry != nil {
+		f.expiry.Stop()
+	}
+	err := fmt.Errorf("%w: %s at %s", ErrDeadlineExceeded, f.label(), t.Format(time.RFC3339Nano))
+	f.expiry = f.clock.AfterFunc(t.Sub(f.clock.Now()), func() {
+		var zero T
+		f.complete(zero, err)
+	})
+	return f
+}
+
+// armTimeoutLocked starts the timer enforcing f.timeout, measured from
+// f.execStart.
+func (f *Future[T]) armTimeoutLocked() {
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+	d := f.timeout
+	err := fmt.Errorf("%w: %s exceeded %v", ErrStageTimeout, f.label(), d)
+	f.timer = f.clock.AfterFunc(d-f.clock.Now().Sub(f.execStart), func() {
+		var zero T
+		f.complete(zero, err)
+	})
+}
@@ -0,0 +1,103 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/sauravbiswasiupr/go-futures/futures/futurestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeoutRejectsOnlySlowStage(t *testing.T) {
+	// The parent takes longer than the stage's deadline, which only counts
+	// once the stage itself runs.
+	parent := futures.NewFuture(func() (int, error) {
+		time.Sleep(40 * time.Millisecond)
+		return 1, nil
+	})
+	quick := futures.Then(parent, func(n int) (int, error) { return n + 1, nil }).WithTimeout(20 * time.Millisecond)
+	v, err := quick.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	stuck := futures.Then(quick, func(int) (int, error) {
+		time.Sleep(time.Second)
+		return 0, nil
+	}).WithTimeout(20 * time.Millisecond)
+	tail := futures.Then(stuck, func(n int) (int, error) { return n, nil })
+
+	_, err = tail.Result()
+	assert.ErrorIs(t, err, futures.ErrStageTimeout)
+	assert.ErrorContains(t, err, "stage 2")
+	assert.Equal(t, futures.Rejected, stuck.State())
+}
+
+func TestWithTimeoutFollowsInjectedClock(t *testing.T) {
+	clock := futurestest.NewClock(time.Now())
+	release := make(chan struct{})
+	defer close(release)
+
+	parent := futures.NewFuture(func() (int, error) { return 1, nil }, futures.WithClock(clock))
+	stage := futures.Then(parent, func(v int) (int, error) {
+		<-release
+		return v, nil
+	}).WithTimeout(time.Hour)
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	_, err := stage.Result()
+	assert.ErrorIs(t, err, futures.ErrStageTimeout)
+}
+
+func TestWithDeadlineFollowsInjectedClock(t *testing.T) {
+	start := time.Now()
+	clock := futurestest.NewClock(start)
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.NewFuture(func() (int, error) { <-release; return 1, nil }, futures.WithClock(clock)).
+		WithDeadline(start.Add(time.Minute))
+
+	clock.Advance(time.Minute)
+	_, err := f.Result()
+	assert.ErrorIs(t, err, futures.ErrDeadlineExceeded)
+	assert.Equal(t, futures.Rejected, f.State())
+}
+
+func TestWithDeadlineCancelsTask(t *testing.T) {
+	stopped := make(chan error, 1)
+	f := futures.NewFutureCtx(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return 0, ctx.Err()
+	}).WithDeadline(time.Now().Add(20 * time.Millisecond))
+	f.Start()
+
+	_, err := f.Result()
+	assert.ErrorIs(t, err, futures.ErrDeadlineExceeded)
+	assert.ErrorIs(t, <-stopped, context.Canceled)
+
+	// Settling in time stops the deadline.
+	ok, err := futures.Resolved(2).WithDeadline(time.Now()).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ok)
+}
+
+func TestStageErrorNamesFailingStage(t *testing.T) {
+	boom := errors.New("bad input")
+	root := futures.NewFuture(func() (string, error) { return "x", nil })
+	parsed := futures.ThenNamed(root, "parse", func(string) (int, error) { return 0, boom })
+	tail := futures.Then(parsed, func(n int) (int, error) { return n, nil })
+
+	_, err := tail.Result()
+	assert.ErrorIs(t, err, boom)
+	var stageErr *futures.StageError
+	if assert.ErrorAs(t, err, &stageErr) {
+		// Later stages pass the failure on without re-attributing it.
+		assert.Equal(t, 1, stageErr.Stage)
+		assert.Equal(t, "parse", stageErr.Name)
+	}
+	assert.EqualError(t, err, "stage 1 (parse): bad input")
+}
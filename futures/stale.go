@@ -0,0 +1,106 @@
+package futures
+
+import "sync"
+
+// StaleStore holds the last known good value for a Degrader. Implementations
+// may be backed by a shared cache; they must be safe for concurrent use.
+type StaleStore[T any] interface {
+	Load() (T, bool)
+	Store(v T)
+}
+
+// Degrader runs computations for a read path and, when one fails, fulfills
+// its future with the last known good value instead of rejecting it. The
+// failure is still recorded and reported to OnDegrade hooks. If no good value
+// is known the failure propagates as usual.
+type Degrader[T any] struct {
+	store StaleStore[T]
+
+	mu        sync.Mutex
+	lastErr   error
+	degraded  uint64
+	onDegrade []func(error)
+}
+
+// NewDegrader creates a Degrader keeping good values in store, or in memory if
+// store is nil.
+func NewDegrader[T any](store StaleStore[T]) *Degrader[T] {
+	if store == nil {
+		store = &memoryStale[T]{}
+	}
+	return &Degrader[T]{store: store}
+}
+
+// Do returns a started future for task. A successful result refreshes the
+// stored value; a failure falls back to it when one exists.
+func (d *Degrader[T]) Do(task func() (T, error)) *Future[T] {
+	f := NewFuture(func() (T, error) {
+		res, err := task()
+		if err == nil {
+			d.store.Store(res)
+			return res, nil
+		}
+
+		stale, ok := d.store.Load()
+		if !ok {
+			return res, err
+		}
+		d.record(err)
+		return stale, nil
+	})
+	f.Start()
+	return f
+}
+
+// OnDegrade registers a hook called with the error whenever a stale value is
+// served in place of a fresh one.
+func (d *Degrader[T]) OnDegrade(cb func(error)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onDegrade = append(d.onDegrade, cb)
+}
+
+// LastError returns the error behind the most recent degraded result.
+func (d *Degrader[T]) LastError() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lastErr
+}
+
+// Degraded returns how many results have been served stale.
+func (d *Degrader[T]) Degraded() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.degraded
+}
+
+func (d *Degrader[T]) record(err error) {
+	d.mu.Lock()
+	d.lastErr = err
+	d.degraded++
+	hooks := make([]func(error), len(d.onDegrade))
+	copy(hooks, d.onDegrade)
+	d.mu.Unlock()
+
+	for _, cb := range hooks {
+		cb(err)
+	}
+}
+
+type memoryStale[T any] struct {
+	mu    sync.Mutex
+	value T
+	ok    bool
+}
+
+func (m *memoryStale[T]) Load() (T, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.value, m.ok
+}
+
+func (m *memoryStale[T]) Store(v T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.value, m.ok = v, true
+}
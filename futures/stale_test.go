@@ -0,0 +1,32 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDegraderServesLastGoodValue(t *testing.T) {
+	d := futures.NewDegrader[string](nil)
+	outage := errors.New("upstream down")
+
+	var hooked error
+	d.OnDegrade(func(err error) { hooked = err })
+
+	// Without a known good value the failure propagates.
+	_, err := d.Do(func() (string, error) { return "", outage }).Result()
+	assert.ErrorIs(t, err, outage)
+
+	v, err := d.Do(func() (string, error) { return "fresh", nil }).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh", v)
+
+	v, err = d.Do(func() (string, error) { return "", outage }).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh", v)
+	assert.ErrorIs(t, d.LastError(), outage)
+	assert.ErrorIs(t, hooked, outage)
+	assert.Equal(t, uint64(1), d.Degraded())
+}
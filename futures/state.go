@@ -1,5 +1,10 @@
 package futures
 
+import (
+	"fmt"
+	"sync/atomic"
+)
+
 // State represents the possible states of a Future
 type State int
 
@@ -8,4 +13,43 @@ const (
 	Running                // Task is currently executing
 	Fulfilled              // Task completed successfully
 	Rejected               // Task completed with an error
+	Cancelled              // Future was cancelled before the task completed
 )
+
+// String returns the name of the state, such as "Fulfilled".
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "Pending"
+	case Running:
+		return "Running"
+	case Fulfilled:
+		return "Fulfilled"
+	case Rejected:
+		return "Rejected"
+	case Cancelled:
+		return "Cancelled"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// settled reports whether s is a terminal state.
+func (s State) settled() bool {
+	return s == Fulfilled || s == Rejected || s == Cancelled
+}
+
+// atomicState holds a future's state so that it can be read without taking
+// the future's lock. Writes still happen under the lock, after the fields
+// they publish.
+type atomicState struct {
+	v atomic.Int32
+}
+
+func (s *atomicState) Load() State {
+	return State(s.v.Load())
+}
+
+func (s *atomicState) Store(st State) {
+	s.v.Store(int32(st))
+}
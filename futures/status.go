@@ -0,0 +1,114 @@
+package futures
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Status is a snapshot of a future, taken with Future.Status, for services
+// exposing background jobs over HTTP and the like. It marshals to JSON as
+//
+//	{"state":"Running","future":"import","stage":"parse","created_at":"...",
+//	 "started_at":"...","queue_wait_seconds":0.002,"duration_seconds":1.5,
+//	 "progress":{"done":30,"total":100,"message":"rows"}}
+//
+// leaving out timestamps that are not set yet, the error of futures that did
+// not fail and the progress of futures that never reported any.
+type Status struct {
+	State     State
+	Future    string // Name given with NewFutureNamed, if any
+	Stage     string // Stage label, as in timelines and logs
+	CreatedAt time.Time
+	StartedAt time.Time // Zero until the future's own work begins
+	SettledAt time.Time // Zero until it settles
+	QueueWait time.Duration
+	Duration  time.Duration
+	Error     string    // Message of the error of a future that failed
+	Progress  *Progress // Latest update of a progress future, if any
+}
+
+// Status returns a consistent snapshot of the future's state, timestamps,
+// error and progress. It never blocks on the future settling.
+func (f *Future[T]) Status() Status {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	s := Status{
+		State:     f.state.Load(),
+		Future:    f.pipeline,
+		Stage:     f.label(),
+		CreatedAt: f.createdAt,
+		StartedAt: f.execStart,
+		SettledAt: f.settledAt,
+		QueueWait: f.queueWaitLocked(),
+		Duration:  f.durationLocked(),
+	}
+	if s.State.settled() && f.err != nil {
+		s.Error = f.err.Error()
+	}
+	if f.reported {
+		p := f.progress
+		s.Progress = &p
+	}
+	return s
+}
+
+type statusJSON struct {
+	State     State         `json:"state"`
+	Future    string        `json:"future,omitempty"`
+	Stage     string        `json:"stage"`
+	CreatedAt time.Time     `json:"created_at"`
+	StartedAt *time.Time    `json:"started_at,omitempty"`
+	SettledAt *time.Time    `json:"settled_at,omitempty"`
+	QueueWait float64       `json:"queue_wait_seconds"`
+	Duration  float64       `json:"duration_seconds"`
+	Error     string        `json:"error,omitempty"`
+	Progress  *progressJSON `json:"progress,omitempty"`
+}
+
+type progressJSON struct {
+	Done    int64  `json:"done"`
+	Total   int64  `json:"total,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Status) MarshalJSON() ([]byte, error) {
+	out := statusJSON{
+		State:     s.State,
+		Future:    s.Future,
+		Stage:     s.Stage,
+		CreatedAt: s.CreatedAt,
+		QueueWait: s.QueueWait.Seconds(),
+		Duration:  s.Duration.Seconds(),
+		Error:     s.Error,
+	}
+	if !s.StartedAt.IsZero() {
+		out.StartedAt = &s.StartedAt
+	}
+	if !s.SettledAt.IsZero() {
+		out.SettledAt = &s.SettledAt
+	}
+	if s.Progress != nil {
+		out.Progress = &progressJSON{Done: s.Progress.Done, Total: s.Progress.Total, Message: s.Progress.Message}
+	}
+	return json.Marshal(out)
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding a state as its
+// name, so that it reads as "Running" rather than 1 in JSON and elsewhere.
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the names
+// produced by MarshalText.
+func (s *State) UnmarshalText(text []byte) error {
+	for st := Pending; st <= Cancelled; st++ {
+		if st.String() == string(text) {
+			*s = st
+			return nil
+		}
+	}
+	return fmt.Errorf("futures: unknown state %q", text)
+}
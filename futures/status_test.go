@@ -0,0 +1,61 @@
+package futures_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusSnapshotsRunningProgressFuture(t *testing.T) {
+	reported := make(chan struct{})
+	release := make(chan struct{})
+	f := futures.NewProgressFuture(func(report func(futures.Progress)) (int, error) {
+		report(futures.Progress{Done: 30, Total: 100, Message: "rows"})
+		close(reported)
+		<-release
+		return 1, nil
+	})
+	f.Start()
+	<-reported
+
+	s := f.Status()
+	assert.Equal(t, futures.Running, s.State)
+	assert.Equal(t, "stage 0", s.Stage)
+	assert.False(t, s.StartedAt.IsZero())
+	assert.True(t, s.SettledAt.IsZero())
+	assert.Equal(t, &futures.Progress{Done: 30, Total: 100, Message: "rows"}, s.Progress)
+
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	var out map[string]any
+	assert.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, "Running", out["state"])
+	assert.Contains(t, out, "started_at")
+	assert.NotContains(t, out, "settled_at")
+	assert.NotContains(t, out, "error")
+	assert.Equal(t, map[string]any{"done": 30.0, "total": 100.0, "message": "rows"}, out["progress"])
+
+	close(release)
+	_, _ = f.Result()
+	assert.Equal(t, futures.Fulfilled, f.Status().State)
+}
+
+func TestStatusOfFailedFutureCarriesError(t *testing.T) {
+	f := futures.Failed[int](errors.New("disk full"))
+	data, err := json.Marshal(f.Status())
+	assert.NoError(t, err)
+	var out map[string]any
+	assert.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, "Rejected", out["state"])
+	assert.Equal(t, "disk full", out["error"])
+	assert.NotContains(t, out, "progress")
+	assert.Equal(t, 0.0, out["duration_seconds"])
+
+	var st futures.State
+	assert.NoError(t, json.Unmarshal([]byte(`"Cancelled"`), &st))
+	assert.Equal(t, futures.Cancelled, st)
+	assert.Error(t, json.Unmarshal([]byte(`"Done"`), &st))
+}
@@ -0,0 +1,210 @@
+package futures
+
+import (
+	"context"
+	"io"
+	"iter"
+	"sync"
+)
+
+// Stream is an asynchronous sequence of values: a producer sends values over
+// time and closes the stream when done, possibly with an error, while a
+// consumer receives them or composes the stream into further streams and
+// futures. Send blocks while the stream's buffer is full, so a slow consumer
+// throttles its producer.
+type Stream[T any] struct {
+	values    chan T
+	stopped   chan struct{}
+	err       error // set before values is closed
+	closeOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// NewStream creates an open stream holding up to buffer values that have been
+// sent but not received yet.
+func NewStream[T any](buffer int) *Stream[T] {
+	return &Stream[T]{
+		values:  make(chan T, buffer),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Send delivers v to the consumer, waiting for buffer space. It returns
+// ErrStreamStopped, dropping v, if the consumer stopped the stream. Send must
+// not be called after Close.
+func (s *Stream[T]) Send(v T) error {
+	select {
+	case <-s.stopped:
+		return ErrStreamStopped
+	default:
+	}
+
+	select {
+	case s.values <- v:
+		return nil
+	case <-s.stopped:
+		return ErrStreamStopped
+	}
+}
+
+// Close ends the stream once the values already sent have been received. A
+// non-nil err is reported to the consumer in place of io.EOF. Only the first
+// call has an effect.
+func (s *Stream[T]) Close(err error) {
+	s.closeOnce.Do(func() {
+		s.err = err
+		close(s.values)
+	})
+}
+
+// Stop tells the producer that no more values will be read. Pending and
+// future Send calls return ErrStreamStopped.
+func (s *Stream[T]) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopped)
+	})
+}
+
+// Recv returns the next value. Once the stream is closed and drained, it
+// returns io.EOF, or the error the stream was closed with. If ctx ends first,
+// it returns ctx.Err().
+func (s *Stream[T]) Recv(ctx context.Context) (T, error) {
+	return s.recv(ctx.Done(), ctx.Err)
+}
+
+func (s *Stream[T]) recv(cancel <-chan struct{}, cause func() error) (T, error) {
+	var zero T
+	select {
+	case v, ok := <-s.values:
+		if !ok {
+			if s.err != nil {
+				return zero, s.err
+			}
+			return zero, io.EOF
+		}
+		return v, nil
+	case <-cancel:
+		return zero, cause()
+	}
+}
+
+// All yields the values of the stream until it is closed. If the stream was
+// closed with an error, it is yielded last with a zero value. Breaking out of
+// the loop stops the stream.
+func (s *Stream[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer s.Stop()
+		for {
+			v, err := s.recv(nil, nil)
+			if err == io.EOF {
+				return
+			}
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ToChannel returns a channel receiving the stream's values as Results, for
+// use in select statements. A closing error arrives as a final Result with a
+// zero value; the channel is then closed. Stop the stream to abandon it early.
+func (s *Stream[T]) ToChannel() <-chan Result[T] {
+	ch := make(chan Result[T])
+	stopped := func() error { return ErrStreamStopped }
+	go func() {
+		defer close(ch)
+		for {
+			v, err := s.recv(s.stopped, stopped)
+			if err == io.EOF || err == ErrStreamStopped {
+				return
+			}
+			select {
+			case ch <- Result[T]{Value: v, Err: err}:
+			case <-s.stopped:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Collect returns a started future resolving with every value of the stream
+// once it is closed, or rejecting with the error it was closed with.
+// Cancelling the future stops the stream.
+func (s *Stream[T]) Collect() *Future[[]T] {
+	f := NewFutureCtx(context.Background(), func(ctx context.Context) ([]T, error) {
+		var out []T
+		for {
+			v, err := s.Recv(ctx)
+			if err == io.EOF {
+				return out, nil
+			}
+			if err != nil {
+				s.Stop()
+				return nil, err
+			}
+			out = append(out, v)
+		}
+	})
+	f.Start()
+	return f
+}
+
+// Filter returns a stream of the values of s for which keep reports true.
+func (s *Stream[T]) Filter(keep func(T) bool) *Stream[T] {
+	return pipe(s, 0, func(v T, send func(T) error) error {
+		if !keep(v) {
+			return nil
+		}
+		return send(v)
+	})
+}
+
+// Buffer returns a stream relaying s through a buffer of n values, letting
+// the producer run up to n values ahead of a slow consumer.
+func (s *Stream[T]) Buffer(n int) *Stream[T] {
+	return pipe(s, n, func(v T, send func(T) error) error {
+		return send(v)
+	})
+}
+
+// MapStream returns a stream of fn applied to each value of s. If fn fails,
+// s is stopped and the returned stream is closed with the error.
+func MapStream[T, U any](s *Stream[T], fn func(T) (U, error)) *Stream[U] {
+	return pipe(s, 0, func(v T, send func(U) error) error {
+		u, err := fn(v)
+		if err != nil {
+			return err
+		}
+		return send(u)
+	})
+}
+
+// pipe feeds the values of in through step into a new stream. The new stream
+// ends like in, or with the first error returned by step; stopping it stops
+// in as well.
+func pipe[T, U any](in *Stream[T], buffer int, step func(v T, send func(U) error) error) *Stream[U] {
+	out := NewStream[U](buffer)
+	go func() {
+		for {
+			v, err := in.recv(out.stopped, func() error { return ErrStreamStopped })
+			if err == io.EOF {
+				out.Close(nil)
+				return
+			}
+			if err == nil {
+				err = step(v, out.Send)
+			}
+			if err != nil {
+				in.Stop()
+				out.Close(err)
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,74 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+// produce sends 1..n on a new stream and closes it with err.
+func produce(n int, err error) *futures.Stream[int] {
+	s := futures.NewStream[int](0)
+	go func() {
+		for i := 1; i <= n; i++ {
+			if s.Send(i) != nil {
+				return
+			}
+		}
+		s.Close(err)
+	}()
+	return s
+}
+
+func TestStreamComposesIntoFuture(t *testing.T) {
+	evens := produce(6, nil).Filter(func(n int) bool { return n%2 == 0 })
+	labels := futures.MapStream(evens.Buffer(2), func(n int) (string, error) {
+		return "#" + strconv.Itoa(n), nil
+	})
+
+	out, err := labels.Collect().Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"#2", "#4", "#6"}, out)
+}
+
+func TestStreamPropagatesProducerError(t *testing.T) {
+	boom := errors.New("boom")
+	s := produce(2, boom)
+
+	v, err := s.Recv(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	_, err = futures.MapStream(s, func(n int) (int, error) { return n, nil }).Collect().Result()
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestStreamStopReleasesProducer(t *testing.T) {
+	s := futures.NewStream[int](0)
+	sent := make(chan error, 1)
+	go func() {
+		for {
+			if err := s.Send(1); err != nil {
+				sent <- err
+				return
+			}
+		}
+	}()
+
+	for v, err := range s.All() {
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+		break
+	}
+	assert.ErrorIs(t, <-sent, futures.ErrStreamStopped)
+
+	closed := futures.NewStream[int](1)
+	closed.Close(nil)
+	_, err := closed.Recv(context.Background())
+	assert.Equal(t, io.EOF, err)
+}
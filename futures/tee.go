@@ -0,0 +1,17 @@
+package futures
+
+// Tee forks f into n futures settling like it, one per consumer. Each can be
+// chained, waited for or cancelled independently without re-running f, and
+// cancelling one only cancels f once every fork has been cancelled.
+//
+// Any number of typed stages can also be chained from f directly with Then;
+// Tee is for handing f to consumers that should not affect one another.
+func Tee[T any](f *Future[T], n int) []*Future[T] {
+	forks := make([]*Future[T], n)
+	for i := range forks {
+		forks[i] = chain(f, func(result T, err error) (T, error) {
+			return result, err
+		})
+	}
+	return forks
+}
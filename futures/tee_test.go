@@ -0,0 +1,37 @@
+package futures_test
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeeRunsParentOnce(t *testing.T) {
+	var runs atomic.Int32
+	parent := futures.NewFuture(func() (int, error) {
+		runs.Add(1)
+		return 21, nil
+	})
+	forks := futures.Tee(parent, 2)
+	doubled := futures.Then(forks[0], func(v int) (int, error) { return v * 2, nil })
+	named := futures.Then(forks[1], func(v int) (string, error) { return strconv.Itoa(v), nil })
+
+	assert.Equal(t, 42, doubled.MustResult())
+	assert.Equal(t, "21", named.MustResult())
+	assert.Equal(t, int32(1), runs.Load())
+}
+
+func TestTeeCancelsParentOnlyWhenAllForksCancel(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	parent := futures.NewFuture(func() (int, error) { <-release; return 1, nil })
+	forks := futures.Tee(parent, 2)
+
+	forks[0].Cancel()
+	assert.False(t, parent.IsDone())
+	forks[1].Cancel()
+	assert.True(t, parent.IsCancelled())
+}
@@ -0,0 +1,25 @@
+package futures
+
+// WithLockedOSThread makes every worker of the executor lock its goroutine to
+// an operating system thread with runtime.LockOSThread for as long as the
+// worker lives, for cgo, FFI or GPU libraries that keep per-thread state. A
+// task, and the stages and callbacks run for it on the executor, then stays
+// on one thread throughout, and no other goroutine runs on that thread. The
+// thread ends with its worker, when the worker retires or the executor shuts
+// down, rather than being reused.
+//
+// To pin a class of futures to a single dedicated thread, give them a pool,
+// or an event loop, with one worker that never retires:
+//
+//	gpu := futures.NewEventLoop(64, futures.WithLockedOSThread())
+//	e := futures.NewExecutor(8, 64,
+//		futures.WithPool("gpu", 1, 64, futures.WithLockedOSThread(), futures.WithMinIdle(1)))
+//
+// OverflowCallerRuns is treated as OverflowBlock, since it would run tasks on
+// the submitter's thread. Pools do not inherit the option, and it has no
+// effect on a direct executor.
+func WithLockedOSThread() ExecutorOption {
+	return func(e *Executor) {
+		e.lockThread = true
+	}
+}
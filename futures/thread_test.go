@@ -0,0 +1,59 @@
+//go:build linux
+
+package futures_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockedOSThreadPinsTasksToOneThread(t *testing.T) {
+	e := futures.NewExecutor(2, 16,
+		futures.WithPool("gpu", 1, 16, futures.WithLockedOSThread(), futures.WithMinIdle(1)))
+	defer e.Shutdown(context.Background())
+	gpu := e.Pool("gpu")
+
+	fs := make([]*futures.Future[int], 5)
+	for i := range fs {
+		fs[i] = futures.Submit(gpu, func() (int, error) {
+			before := syscall.Gettid()
+			time.Sleep(time.Millisecond)
+			if after := syscall.Gettid(); after != before {
+				return 0, assert.AnError
+			}
+			return before, nil
+		})
+	}
+	tids, err := futures.All(fs...).Result()
+	assert.NoError(t, err)
+	for _, tid := range tids {
+		assert.Equal(t, tids[0], tid)
+	}
+}
+
+func TestLockedOSThreadNeverRunsOnCaller(t *testing.T) {
+	e := futures.NewExecutor(1, 0, futures.WithLockedOSThread(), futures.WithOverflow(futures.OverflowCallerRuns))
+	defer e.Shutdown(context.Background())
+
+	release := make(chan struct{})
+	busy := futures.Submit(e, func() (int, error) {
+		<-release
+		return syscall.Gettid(), nil
+	})
+	queued := make(chan *futures.Future[int])
+	go func() {
+		// The queue is full, so this blocks instead of running on this goroutine.
+		queued <- futures.Submit(e, func() (int, error) { return syscall.Gettid(), nil })
+	}()
+	close(release)
+	worker, err := busy.Result()
+	assert.NoError(t, err)
+	second, err := (<-queued).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, worker, second)
+}
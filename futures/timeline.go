@@ -0,0 +1,115 @@
+package futures
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Span is the execution of one future recorded on a Timeline. It covers the
+// future's own work only, not the time spent waiting for its parent.
+type Span struct {
+	Name  string
+	Stage int
+	Start time.Time
+	End   time.Time
+	Err   error
+}
+
+// Timeline records when each future of a chain ran, making overlap and
+// serialization bottlenecks visible. Attach it to the root of a chain with
+// WithTimeline before chaining further steps.
+type Timeline struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+// NewTimeline creates an empty timeline.
+func NewTimeline() *Timeline {
+	return &Timeline{}
+}
+
+// WithTimeline records f, and every future chained from it afterwards, on t.
+// It must be called before f starts and returns f for convenience.
+func (f *Future[T]) WithTimeline(t *Timeline) *Future[T] {
+	f.mutex.Lock()
+	f.timeline = t
+	f.mutex.Unlock()
+	return f
+}
+
+func (t *Timeline) add(s Span) {
+	t.mu.Lock()
+	t.spans = append(t.spans, s)
+	t.mu.Unlock()
+}
+
+// Spans returns the recorded spans ordered by start time.
+func (t *Timeline) Spans() []Span {
+	t.mu.Lock()
+	spans := append([]Span(nil), t.spans...)
+	t.mu.Unlock()
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start.Before(spans[j].Start) })
+	return spans
+}
+
+type traceEvent struct {
+	Name     string         `json:"name"`
+	Phase    string         `json:"ph"`
+	Start    int64          `json:"ts"`
+	Duration int64          `json:"dur"`
+	Pid      int            `json:"pid"`
+	Tid      int            `json:"tid"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes the timeline in the Chrome trace event format,
+// which chrome://tracing and Perfetto can display. Overlapping spans are laid
+// out on separate rows.
+func (t *Timeline) WriteChromeTrace(w io.Writer) error {
+	spans := t.Spans()
+
+	var origin time.Time
+	if len(spans) > 0 {
+		origin = spans[0].Start
+	}
+
+	var laneEnds []time.Time
+	events := make([]traceEvent, 0, len(spans))
+	for _, s := range spans {
+		// Reuse the first row that is free by the time this span starts.
+		lane := len(laneEnds)
+		for i, end := range laneEnds {
+			if !end.After(s.Start) {
+				lane = i
+				break
+			}
+		}
+		if lane == len(laneEnds) {
+			laneEnds = append(laneEnds, s.End)
+		} else {
+			laneEnds[lane] = s.End
+		}
+
+		args := map[string]any{"stage": s.Stage}
+		if s.Err != nil {
+			args["error"] = s.Err.Error()
+		}
+		events = append(events, traceEvent{
+			Name:     s.Name,
+			Phase:    "X",
+			Start:    s.Start.Sub(origin).Microseconds(),
+			Duration: s.End.Sub(s.Start).Microseconds(),
+			Pid:      1,
+			Tid:      lane + 1,
+			Args:     args,
+		})
+	}
+
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}{events})
+}
@@ -0,0 +1,43 @@
+package futures_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimelineRecordsEveryStage(t *testing.T) {
+	tl := futures.NewTimeline()
+
+	chain := futures.NewFuture(func() (any, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 1, nil
+	}).WithTimeline(tl).Then(func(v any) (any, error) {
+		return v.(int) + 1, nil
+	})
+
+	result, err := chain.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result)
+
+	spans := tl.Spans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "stage 0", spans[0].Name)
+	assert.Equal(t, "stage 1", spans[1].Name)
+	// The second stage's span starts after the first finished, not when it
+	// began waiting on it.
+	assert.False(t, spans[1].Start.Before(spans[0].End))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tl.WriteChromeTrace(&buf))
+	var trace struct {
+		TraceEvents []map[string]any `json:"traceEvents"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	assert.Len(t, trace.TraceEvents, 2)
+	assert.Equal(t, "X", trace.TraceEvents[0]["ph"])
+}
@@ -0,0 +1,102 @@
+package futures
+
+import (
+	"slices"
+	"sync"
+)
+
+// CancelToken cancels many unrelated futures at once, such as everything
+// started on behalf of a request the user has abandoned, without threading a
+// context through every place they are created. Futures are attached with
+// NewFutureWithToken, or by passing WithCancelToken to a helper.
+type CancelToken struct {
+	mu        sync.Mutex
+	cancelled bool
+	done      chan struct{}
+	next      uint64
+	attached  map[uint64]func() bool // Cancel of each attached future still pending
+}
+
+// NewCancelToken creates a token that has not been cancelled.
+func NewCancelToken() *CancelToken {
+	return &CancelToken{done: make(chan struct{}), attached: make(map[uint64]func() bool)}
+}
+
+// WithCancelToken attaches the futures a helper creates to t, as with
+// NewFutureWithToken. Every helper taking options honours it except
+// NewScheduler, whose runs end with its context, and NewRateLimiter, which
+// creates no futures. Helpers that return one future (Retry, WaitFor, Sleep,
+// Hedged, MapConcurrent, ForEach, ...) attach it; long-lived helpers (Cache,
+// SingleFlight, CircuitBreaker, Coalescer, Debounce) attach every future
+// they hand out, so that once t is cancelled they only hand out cancelled
+// futures.
+func WithCancelToken(t *CancelToken) Option {
+	return func(o *options) {
+		o.token = t
+	}
+}
+
+// NewFutureWithToken is NewFuture for a future attached to token: cancelling
+// the token cancels the future, as with Future.Cancel, unless it has settled
+// already. A future created with a cancelled token starts out cancelled.
+func NewFutureWithToken[T any](token *CancelToken, task func() (T, error), opts ...Option) *Future[T] {
+	return newFuture(task, nil, buildOptions(append(slices.Clip(opts), WithCancelToken(token))))
+}
+
+// Cancel cancels every future attached to t and marks it cancelled, so that
+// futures attached later are cancelled right away. Calling it again has no
+// effect.
+func (t *CancelToken) Cancel() {
+	t.mu.Lock()
+	if t.cancelled {
+		t.mu.Unlock()
+		return
+	}
+	t.cancelled = true
+	close(t.done)
+	attached := t.attached
+	t.attached = nil
+	t.mu.Unlock()
+
+	for _, cancel := range attached {
+		cancel()
+	}
+}
+
+// Cancelled reports whether t has been cancelled.
+func (t *CancelToken) Cancelled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cancelled
+}
+
+// Done returns a channel closed once t is cancelled, for tasks that want to
+// stop their own work early.
+func (t *CancelToken) Done() <-chan struct{} {
+	return t.done
+}
+
+// attachToken makes cancelling t cancel f. A nil t leaves f alone.
+func attachToken[T any](t *CancelToken, f *Future[T]) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	if t.cancelled {
+		t.mu.Unlock()
+		f.Cancel()
+		return
+	}
+	id := t.next
+	t.next++
+	t.attached[id] = f.Cancel
+	t.mu.Unlock()
+
+	// Settled futures let go of the token, so long-lived tokens do not pile
+	// them up.
+	f.whenSettled(func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.attached, id)
+	})
+}
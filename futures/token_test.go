@@ -0,0 +1,118 @@
+package futures_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelTokenCancelsAttachedFutures(t *testing.T) {
+	token := futures.NewCancelToken()
+
+	block := func() (int, error) {
+		<-token.Done()
+		return 0, nil
+	}
+	a := futures.NewFutureWithToken(token, block)
+	a.Start()
+	b := futures.NewFutureWithToken(token, func() (string, error) { return "b", nil })
+	chained := futures.Then(a, func(n int) (int, error) { return n + 1, nil })
+	retried := futures.Retry(block, futures.MaxAttempts(futures.ConstantBackoff(time.Millisecond), 3), futures.WithCancelToken(token))
+	done := futures.NewFutureWithToken(token, func() (int, error) { return 1, nil })
+	_, err := done.Result()
+	assert.NoError(t, err)
+
+	token.Cancel()
+	token.Cancel()
+	assert.True(t, token.Cancelled())
+	_, err = a.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	_, err = b.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	_, err = chained.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	_, err = retried.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	assert.True(t, done.IsSuccess())
+
+	late := futures.NewFutureWithToken(token, func() (int, error) { return 1, nil })
+	assert.True(t, late.IsCancelled())
+}
+
+func TestCancelTokenReachesCombinators(t *testing.T) {
+	token := futures.NewCancelToken()
+	var calls atomic.Int32
+	wait := func(ctx context.Context, _ int) error {
+		calls.Add(1)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	each := futures.ForEach(context.Background(), make([]int, 8), wait, 2, futures.WithCancelToken(token))
+	mapped := futures.MapConcurrent(context.Background(), make([]int, 8), func(ctx context.Context, i int) (int, error) {
+		return i, wait(ctx, i)
+	}, 2, futures.WithCancelToken(token))
+	hedged := futures.Hedged(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, time.Millisecond, 3, futures.WithCancelToken(token))
+	slept := futures.Sleep(context.Background(), time.Hour, futures.WithCancelToken(token))
+
+	token.Cancel()
+	_, err := each.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	_, err = mapped.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	_, err = hedged.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	_, err = slept.Result()
+	assert.ErrorIs(t, err, futures.ErrCancelled)
+	assert.LessOrEqual(t, calls.Load(), int32(4), "cancelling the token stops the remaining items")
+}
+
+func TestCancelTokenReachesLongLivedHelpers(t *testing.T) {
+	token := futures.NewCancelToken()
+	block := func() (int, error) {
+		<-token.Done()
+		return 1, nil
+	}
+
+	cache := futures.NewCache[string, int](futures.CacheConfig{}, futures.WithCancelToken(token))
+	flights := futures.NewSingleFlight[string, int](futures.WithCancelToken(token))
+	breaker := futures.NewCircuitBreaker[int](futures.BreakerConfig{}, futures.WithCancelToken(token))
+	coalescer := futures.NewCoalescer(func(keys []string) (map[string]int, error) {
+		return map[string]int{}, nil
+	}, time.Hour, 0, futures.WithCancelToken(token))
+	var debounced atomic.Int32
+	debounce := futures.Debounce(func(n int) (int, error) {
+		debounced.Add(1)
+		return n, nil
+	}, 10*time.Millisecond, futures.WithCancelToken(token))
+
+	fs := []*futures.Future[int]{
+		cache.GetOrCompute("a", block),
+		flights.Do("a", block),
+		breaker.Do(block),
+		coalescer.Load("a"),
+		debounce(1),
+	}
+	token.Cancel()
+	for _, f := range fs {
+		_, err := f.Result()
+		assert.ErrorIs(t, err, futures.ErrCancelled)
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.Zero(t, debounced.Load(), "a cancelled debounced call never runs")
+
+	// Once the token is cancelled, the helpers only hand out cancelled
+	// futures.
+	assert.True(t, cache.GetOrCompute("b", block).IsCancelled())
+	assert.True(t, flights.Do("b", block).IsCancelled())
+	assert.True(t, breaker.Do(block).IsCancelled())
+	assert.True(t, coalescer.Load("b").IsCancelled())
+	assert.True(t, debounce(2).IsCancelled())
+	assert.Equal(t, futures.BreakerClosed, breaker.State())
+}
@@ -0,0 +1,48 @@
+package futures
+
+import "errors"
+
+// dependOn records that d consumes the outcome of in. Each future counts its
+// live consumers; when the last one is cancelled or times out, the future is
+// cancelled as well, so abandoned pipelines stop running. Consumers that
+// settle any other way release their hold without cancelling anything.
+func dependOn[T, U any](d *Future[U], in *Future[T]) {
+	in.retain()
+	d.mutex.Lock()
+	if d.upstream == nil {
+		d.upstream = d.upBuf[:0]
+	}
+	d.upstream = append(d.upstream, in)
+	d.mutex.Unlock()
+}
+
+// releaser is an input held through dependOn.
+type releaser interface {
+	release(abandon bool)
+}
+
+func (f *Future[T]) retain() {
+	f.consumers.Add(1)
+}
+
+// release drops one consumer. If abandon is set and that was the last one,
+// f is cancelled unless it has settled already.
+func (f *Future[T]) release(abandon bool) {
+	if f.consumers.Add(-1) == 0 && abandon {
+		f.Cancel()
+	}
+}
+
+// abandons reports whether a consumer settling with err has given up on its
+// inputs rather than used them.
+func abandons(err error) bool {
+	return errors.Is(err, ErrCancelled) || errors.Is(err, ErrStageTimeout) || errors.Is(err, ErrDeadlineExceeded)
+}
+
+// await starts f and waits for its outcome without counting as a consumer,
+// for helpers that already hold f through dependOn.
+func (f *Future[T]) await() (T, error) {
+	f.Start()
+	<-f.doneChan()
+	return f.outcome()
+}
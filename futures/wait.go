@@ -0,0 +1,57 @@
+package futures
+
+import (
+	"context"
+	"time"
+)
+
+// WaitFor returns a started future that polls check every interval and
+// resolves with the value check reports once it is satisfied. The future
+// rejects with ctx.Err() if ctx ends first. Cancelling the future stops the
+// polling. Under StartLazy polling waits for a consumer instead.
+func WaitFor[T any](ctx context.Context, check func() (T, bool), interval time.Duration, opts ...Option) *Future[T] {
+	o := buildOptions(opts)
+
+	f := newSelfFuture(o, func(f *Future[T]) (T, error) {
+		for {
+			if v, ok := check(); ok {
+				return v, nil
+			}
+
+			select {
+			case <-ctx.Done():
+				var zero T
+				return zero, ctx.Err()
+			case <-f.settled():
+				// Cancelled; the outcome is discarded.
+				var zero T
+				return zero, ErrCancelled
+			case <-o.clock.After(interval):
+			}
+		}
+	})
+	if o.start == StartOnDemand {
+		f.Start()
+	}
+	return f
+}
+
+// Sleep returns a started future that resolves after d, or rejects with
+// ctx.Err() as soon as ctx ends, so pacing steps stop promptly on shutdown.
+// Under StartLazy the wait begins with the first consumer instead.
+func Sleep(ctx context.Context, d time.Duration, opts ...Option) *Future[struct{}] {
+	o := buildOptions(opts)
+
+	f := NewFuture(func() (struct{}, error) {
+		select {
+		case <-ctx.Done():
+			return struct{}{}, ctx.Err()
+		case <-o.clock.After(d):
+			return struct{}{}, nil
+		}
+	}, opts...)
+	if o.start == StartOnDemand {
+		f.Start()
+	}
+	return f
+}
@@ -0,0 +1,101 @@
+package futures_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sauravbiswasiupr/go-futures/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+// tickClock is a Clock whose timers fire whenever the test sends a tick.
+type tickClock struct {
+	ticks chan time.Time
+}
+
+func (c *tickClock) Now() time.Time { return time.Time{} }
+
+func (c *tickClock) After(time.Duration) <-chan time.Time { return c.ticks }
+
+func (c *tickClock) AfterFunc(time.Duration, func()) futures.Timer {
+	panic("tickClock: AfterFunc not supported")
+}
+
+func TestWaitForPollsUntilSatisfied(t *testing.T) {
+	clock := &tickClock{ticks: make(chan time.Time)}
+	var calls atomic.Int32
+
+	f := futures.WaitFor(context.Background(), func() (int32, bool) {
+		n := calls.Add(1)
+		return n, n == 3
+	}, time.Hour, futures.WithClock(clock))
+
+	// Two polls fail, so the third check only happens after two ticks.
+	clock.ticks <- time.Time{}
+	clock.ticks <- time.Time{}
+
+	result, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), result)
+}
+
+func TestWaitForStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := futures.WaitFor(ctx, func() (struct{}, bool) {
+		return struct{}{}, false
+	}, time.Hour)
+
+	cancel()
+	_, err := f.Result()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWaitForStopsPollingOnCancel(t *testing.T) {
+	var checks atomic.Int32
+	f := futures.WaitFor(context.Background(), func() (int, bool) {
+		checks.Add(1)
+		return 0, false
+	}, time.Millisecond)
+	assert.Eventually(t, func() bool { return checks.Load() > 3 }, time.Second, time.Millisecond)
+
+	f.Cancel()
+	// A check already under way may still finish.
+	atCancel := checks.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.LessOrEqual(t, checks.Load(), atCancel+1)
+}
+
+func TestSleepResolvesAfterDuration(t *testing.T) {
+	clock := &tickClock{ticks: make(chan time.Time, 1)}
+	f := futures.Sleep(context.Background(), time.Hour, futures.WithClock(clock))
+
+	clock.ticks <- time.Time{}
+	_, err := f.Result()
+	assert.NoError(t, err)
+}
+
+func TestSleepRejectsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := futures.Sleep(ctx, time.Hour)
+
+	cancel()
+	_, err := f.Result()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWaitForFollowsStartPolicy(t *testing.T) {
+	var calls atomic.Int32
+	f := futures.WaitFor(context.Background(), func() (int32, bool) {
+		return calls.Add(1), true
+	}, time.Hour, futures.WithStartPolicy(futures.StartLazy))
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), calls.Load())
+	assert.Equal(t, futures.Pending, f.State())
+
+	result, err := f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), result)
+}
@@ -0,0 +1,73 @@
+package futures
+
+import "sync/atomic"
+
+// Awaitable is implemented by every future type of this package, whatever
+// its result type, so that differently-typed futures can be awaited together.
+type Awaitable interface {
+	// Start starts the future if it has not been started yet.
+	Start()
+	// wait blocks until the future settles and returns its error.
+	wait() error
+}
+
+func (f *Future[T]) wait() error {
+	_, err := f.Result()
+	return err
+}
+
+func (f *Future2[A, B]) wait() error {
+	return f.f.wait()
+}
+
+func (f *Future3[A, B, C]) wait() error {
+	return f.f.wait()
+}
+
+// Join starts fs, which may have different result types, and returns a
+// future that resolves once all of them have succeeded. It rejects with the
+// first error that occurs.
+func Join(fs ...Awaitable) *Future[struct{}] {
+	p := NewPromise[struct{}]()
+	if len(fs) == 0 {
+		p.Complete(struct{}{})
+		return p.Future()
+	}
+
+	var remaining atomic.Int64
+	remaining.Store(int64(len(fs)))
+	for _, f := range fs {
+		f.Start()
+		go func() {
+			if err := f.wait(); err != nil {
+				p.Fail(err)
+				return
+			}
+			if remaining.Add(-1) == 0 {
+				p.Complete(struct{}{})
+			}
+		}()
+	}
+	return p.Future()
+}
+
+// Zip2 runs fa and fb concurrently and combines their results. It rejects as
+// soon as either fails. Use Future() on the result for a *Future[Tuple2].
+func Zip2[A, B any](fa *Future[A], fb *Future[B]) *Future2[A, B] {
+	return &Future2[A, B]{f: Then(Join(fa, fb), func(struct{}) (Tuple2[A, B], error) {
+		a, _ := fa.outcome()
+		b, _ := fb.outcome()
+		return Tuple2[A, B]{a, b}, nil
+	})}
+}
+
+// Zip3 runs three futures concurrently and combines their results. It
+// rejects as soon as any of them fails.
+func Zip3[A, B, C any](fa *Future[A], fb *Future[B], fc *Future[C]) *Future3[A, B, C] {
+	return &Future3[A, B, C]{f: Then(Join(fa, fb, fc), func(struct{}) (Tuple3[A, B, C], error) {
+		a, _ := fa.outcome()
+		b, _ := fb.outcome()
+		c, _ := fc.outcome()
+		return Tuple3[A, B, C]{a, b, c}, nil
+	})}
+}